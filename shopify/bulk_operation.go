@@ -0,0 +1,301 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const bulkOperationRunQueryMutation = `mutation bulkOperationRunQuery($query: String!) {
+  bulkOperationRunQuery(query: $query) {
+    bulkOperation {
+      id
+      status
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const bulkOperationQuery = `query bulkOperation($id: ID!) {
+  node(id: $id) {
+    ... on BulkOperation {
+      id
+      status
+      errorCode
+      objectCount
+      url
+    }
+  }
+}`
+
+const currentBulkOperationQuery = `query {
+  currentBulkOperation(type: QUERY) {
+    id
+    status
+  }
+}`
+
+const bulkOperationCancelMutation = `mutation bulkOperationCancel($id: ID!) {
+  bulkOperationCancel(id: $id) {
+    bulkOperation {
+      id
+      status
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// the max time to wait for a bulk operation to be cancelled once the query is done
+const bulkOperationCancelTimeout = 30 * time.Second
+
+// the max interval between two polls of a running bulk operation
+const bulkOperationMaxPollInterval = 10 * time.Second
+
+// BulkOperation is the state of a bulk operation
+type BulkOperation struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	ErrorCode   string `json:"errorCode"`
+	ObjectCount string `json:"objectCount"`
+	URL         string `json:"url"`
+}
+
+// BulkOperationError is returned when a bulk operation can't be started or doesn't complete
+type BulkOperationError struct {
+	ID        string
+	Status    string
+	ErrorCode string
+	Messages  []string
+}
+
+func (e BulkOperationError) Error() string {
+	if len(e.Messages) > 0 {
+		return "bulk operation: " + strings.Join(e.Messages, "; ")
+	}
+	return fmt.Sprintf("bulk operation %s: status %s, error code %s", e.ID, e.Status, e.ErrorCode)
+}
+
+// bulkOperationTables are the tables that can be listed with a bulk operation.
+// The other tables that reuse their list functions as a parent hydrate always page
+// through the REST API, since they usually look up a few rows.
+var bulkOperationTables = map[string]bool{
+	"shopify_order":           true,
+	"shopify_product":         true,
+	"shopify_product_variant": true,
+	"shopify_customer":        true,
+}
+
+// useBulkOperations returns true if a table should be listed with a bulk operation.
+// Bulk operations are opt-in, and are only worth it when the whole table is read,
+// i.e. when the query has neither a limit nor a qual on a key column.
+func useBulkOperations(d *plugin.QueryData) bool {
+	shopifyConfig := GetConfig(d.Connection)
+	if shopifyConfig.BulkOperations == nil || !*shopifyConfig.BulkOperations {
+		return false
+	}
+	if !bulkOperationTables[d.Table.Name] {
+		return false
+	}
+	return d.QueryContext.Limit == nil && len(d.Quals) == 0
+}
+
+// checkBulkOperationColumns returns an error if the query selects a column that isn't
+// in the result of the bulk operation of the table, rather than returning it as null
+func checkBulkOperationColumns(d *plugin.QueryData, columns []string) error {
+	var unsupported []string
+	for _, column := range d.QueryContext.Columns {
+		if column == "shop_name" || plugin.IsReservedColumnName(column) || slices.Contains(columns, column) {
+			continue
+		}
+		unsupported = append(unsupported, column)
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%s can't be listed with bulk operations: remove the columns from the query, add a limit or set bulk_operations = false in the connection config", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// RunBulkQuery runs a bulk operation for the query, waits for it to complete and
+// calls fn with each object of the result until fn returns false. The objects of
+// nested connections are returned after their parent object, along with the ID of the parent.
+func (c *GraphQLClient) RunBulkQuery(ctx context.Context, query string, fn func(object json.RawMessage, parentID string) (bool, error)) error {
+	url, err := c.runBulkOperation(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	// The URL is empty if the query didn't return any objects
+	if url == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	// The result can be several GB, so the download doesn't use the timeout of the API client
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bulk operation: downloading the result failed with status %s", resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var object json.RawMessage
+		if err := decoder.Decode(&object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		var parent struct {
+			ParentID string `json:"__parentId"`
+		}
+		if err := json.Unmarshal(object, &parent); err != nil {
+			return err
+		}
+
+		more, err := fn(object, parent.ParentID)
+		if err != nil || !more {
+			return err
+		}
+	}
+}
+
+// runBulkOperation starts a bulk operation and returns the URL of its result once it completes
+func (c *GraphQLClient) runBulkOperation(ctx context.Context, query string) (string, error) {
+	// Shopify runs a single bulk query at a time for each app and shop
+	c.bulkMu.Lock()
+	defer c.bulkMu.Unlock()
+
+	// Another bulk query of the app, e.g. one started by another Steampipe instance, would
+	// make the new one fail with a less helpful message
+	var current struct {
+		CurrentBulkOperation *BulkOperation `json:"currentBulkOperation"`
+	}
+	if _, err := c.Query(ctx, currentBulkOperationQuery, nil, &current); err != nil {
+		return "", err
+	}
+	if op := current.CurrentBulkOperation; op != nil && bulkOperationRunning(op.Status) {
+		return "", BulkOperationError{
+			ID:       op.ID,
+			Status:   op.Status,
+			Messages: []string{fmt.Sprintf("%s is already %s for this app, wait for it to complete or cancel it with the bulkOperationCancel mutation", op.ID, strings.ToLower(op.Status))},
+		}
+	}
+
+	var started struct {
+		BulkOperationRunQuery struct {
+			BulkOperation *BulkOperation `json:"bulkOperation"`
+			UserErrors    []struct {
+				Message string `json:"message"`
+			} `json:"userErrors"`
+		} `json:"bulkOperationRunQuery"`
+	}
+	if _, err := c.Query(ctx, bulkOperationRunQueryMutation, map[string]interface{}{"query": query}, &started); err != nil {
+		return "", err
+	}
+	if userErrors := started.BulkOperationRunQuery.UserErrors; len(userErrors) > 0 {
+		bulkErr := BulkOperationError{}
+		for _, userError := range userErrors {
+			bulkErr.Messages = append(bulkErr.Messages, userError.Message)
+		}
+		return "", bulkErr
+	}
+	if started.BulkOperationRunQuery.BulkOperation == nil {
+		return "", errors.New("bulk operation: the operation was not created")
+	}
+
+	id := started.BulkOperationRunQuery.BulkOperation.ID
+
+	// Shopify keeps running the operation if the query is cancelled or fails while it
+	// polls, which would block the next bulk queries of the app until it completes
+	running := true
+	defer func() {
+		if running {
+			c.cancelBulkOperation(ctx, id)
+		}
+	}()
+
+	interval := time.Second
+	for {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if interval < bulkOperationMaxPollInterval {
+			interval *= 2
+		}
+
+		var result struct {
+			Node *BulkOperation `json:"node"`
+		}
+		if _, err := c.Query(ctx, bulkOperationQuery, map[string]interface{}{"id": id}, &result); err != nil {
+			return "", err
+		}
+		if result.Node == nil {
+			return "", fmt.Errorf("bulk operation %s: not found", id)
+		}
+
+		plugin.Logger(ctx).Debug("GraphQLClient.runBulkOperation", "id", id, "status", result.Node.Status, "object_count", result.Node.ObjectCount)
+		if bulkOperationRunning(result.Node.Status) {
+			continue
+		}
+		running = false
+		if result.Node.Status == "COMPLETED" {
+			return result.Node.URL, nil
+		}
+		return "", BulkOperationError{ID: id, Status: result.Node.Status, ErrorCode: result.Node.ErrorCode}
+	}
+}
+
+// cancelBulkOperation cancels a running bulk operation. The context of the query may already
+// be cancelled, so the request has its own timeout, and a failure is only logged.
+func (c *GraphQLClient) cancelBulkOperation(ctx context.Context, id string) {
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), bulkOperationCancelTimeout)
+	defer cancel()
+
+	var result struct {
+		BulkOperationCancel struct {
+			UserErrors []struct {
+				Message string `json:"message"`
+			} `json:"userErrors"`
+		} `json:"bulkOperationCancel"`
+	}
+	if _, err := c.Query(cancelCtx, bulkOperationCancelMutation, map[string]interface{}{"id": id}, &result); err != nil {
+		plugin.Logger(ctx).Error("GraphQLClient.cancelBulkOperation", "api_error", err, "id", id)
+		return
+	}
+	for _, userError := range result.BulkOperationCancel.UserErrors {
+		plugin.Logger(ctx).Error("GraphQLClient.cancelBulkOperation", "user_error", userError.Message, "id", id)
+	}
+}
+
+// bulkOperationRunning returns true if a bulk operation with the status hasn't completed yet
+func bulkOperationRunning(status string) bool {
+	switch status {
+	case "CREATED", "RUNNING", "CANCELING":
+		return true
+	}
+	return false
+}
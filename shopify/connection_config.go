@@ -4,15 +4,26 @@ import (
 	"context"
 	"errors"
 	"os"
+	"regexp"
 
 	goshopify "github.com/bold-commerce/go-shopify/v3"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
+// defaultGraphQLAPIVersion is the GraphQL Admin API version used when `api_version` isn't
+// set in the connection config. The GraphQL API has no unversioned endpoint, unlike the
+// REST API, which then serves the oldest supported version. Shopify supports each version
+// for 12 months, so this must be bumped to a recent version before each release.
+const defaultGraphQLAPIVersion = "2026-10"
+
+var apiVersionRegex = regexp.MustCompile(`^\d{4}-\d{2}$`)
+
 type shopifyConfig struct {
-	APIToken *string `hcl:"api_token"`
-	ShopName *string `hcl:"shop_name"`
+	APIToken       *string `hcl:"api_token"`
+	ShopName       *string `hcl:"shop_name"`
+	APIVersion     *string `hcl:"api_version"`
+	BulkOperations *bool   `hcl:"bulk_operations"`
 }
 
 func ConfigInstance() interface{} {
@@ -28,6 +39,19 @@ func GetConfig(connection *plugin.Connection) shopifyConfig {
 	return config
 }
 
+// getAPIVersion returns the Admin API version set in the connection config, shared by the
+// REST and the GraphQL clients, or an empty string if it isn't set
+func getAPIVersion(config shopifyConfig) (string, error) {
+	if config.APIVersion == nil {
+		return "", nil
+	}
+	apiVersion := *config.APIVersion
+	if apiVersion != goshopify.UnstableApiVersion && !apiVersionRegex.MatchString(apiVersion) {
+		return "", errors.New("'api_version' must be a version like \"2026-10\" or \"unstable\". Edit your connection configuration file and then restart Steampipe")
+	}
+	return apiVersion, nil
+}
+
 func connect(_ context.Context, d *plugin.QueryData) (*goshopify.Client, error) {
 	// Load connection from cache, which preserves throttling protection etc
 	cacheKey := "shopify"
@@ -59,7 +83,16 @@ func connect(_ context.Context, d *plugin.QueryData) (*goshopify.Client, error)
 	// Currently we don't need to specify the API Key/API Secret Key, to create the
 	// conn, just the API api_token is enough to fetch the data(for the initial tables).
 	// TODO: Look into if we need to use keys/secret keys in the future.
-	conn := goshopify.NewClient(goshopify.App{}, shopName, apiToken)
+	apiVersion, err := getAPIVersion(shopifyConfig)
+	if err != nil {
+		return nil, err
+	}
+	// Without api_version, the REST API serves its default version as it always did
+	var opts []goshopify.Option
+	if apiVersion != "" {
+		opts = append(opts, goshopify.WithVersion(apiVersion))
+	}
+	conn := goshopify.NewClient(goshopify.App{}, shopName, apiToken, opts...)
 
 	return conn, nil
 }
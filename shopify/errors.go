@@ -2,8 +2,11 @@ package shopify
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"strings"
 
+	goshopify "github.com/bold-commerce/go-shopify/v3"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
@@ -19,3 +22,21 @@ func isNotFoundError(notFoundErrors []string) plugin.ErrorPredicateWithContext {
 		return false
 	}
 }
+
+// isUnavailableError:: returns true if the API refused the request because the
+// resource isn't available for the store's plan or the token's access scopes
+func isUnavailableError(err error) bool {
+	var responseError goshopify.ResponseError
+	if errors.As(err, &responseError) {
+		switch responseError.Status {
+		case http.StatusPaymentRequired, http.StatusForbidden, http.StatusNotFound:
+			return true
+		}
+	}
+	// the GraphQL API reports missing access scopes in the response body
+	var graphQLErrors GraphQLErrors
+	if errors.As(err, &graphQLErrors) {
+		return graphQLErrors.HasCode("ACCESS_DENIED")
+	}
+	return false
+}
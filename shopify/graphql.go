@@ -0,0 +1,456 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/shopspring/decimal"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	// the max page size defined by the GraphQL API is 250
+	graphQLMaxPageSize = 250
+
+	// the number of times a throttled query is retried before giving up
+	graphQLMaxThrottleRetries = 5
+)
+
+// GraphQLClient sends queries to the GraphQL Admin API. It reuses the REST client for
+// the credentials, the HTTP client and the error handling, and keeps track of the
+// query cost budget so that queries wait for the budget to refill instead of being throttled.
+type GraphQLClient struct {
+	rest *goshopify.Client
+	path string
+
+	mu       sync.Mutex
+	throttle *GraphQLThrottleStatus
+	// the time the throttle status was last reported by the API
+	throttleAt time.Time
+	// the last requested cost of each query, used to estimate the cost of the next call
+	queryCosts map[string]int
+
+	// serializes the bulk operations of the connection
+	bulkMu sync.Mutex
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data       json.RawMessage `json:"data"`
+	Errors     []GraphQLError  `json:"errors"`
+	Extensions struct {
+		Cost *GraphQLCost `json:"cost"`
+	} `json:"extensions"`
+}
+
+// GraphQLCost is the cost of a query, as reported in the `extensions` of the response
+type GraphQLCost struct {
+	RequestedQueryCost int                    `json:"requestedQueryCost"`
+	ActualQueryCost    int                    `json:"actualQueryCost"`
+	ThrottleStatus     *GraphQLThrottleStatus `json:"throttleStatus"`
+}
+
+// GraphQLThrottleStatus is the state of the query cost budget of the app
+type GraphQLThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"`
+}
+
+// GraphQLPageInfo is the pagination information of a connection
+type GraphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GraphQLConnection is a page of a connection that selects `nodes` and `pageInfo`.
+// The few connections without `nodes`, e.g. customerSegmentMembers, select `edges` instead.
+type GraphQLConnection[T any] struct {
+	Nodes []T `json:"nodes"`
+	Edges []struct {
+		Node T `json:"node"`
+	} `json:"edges"`
+	PageInfo GraphQLPageInfo `json:"pageInfo"`
+}
+
+// graphQLMoney is an amount and its currency
+type graphQLMoney struct {
+	Amount       *decimal.Decimal `json:"amount"`
+	CurrencyCode string           `json:"currencyCode"`
+}
+
+// graphQLCount is the number of resources, as returned by the `...Count` fields
+type graphQLCount struct {
+	Count int `json:"count"`
+}
+
+// GraphQLError is an error returned in the `errors` of a GraphQL response
+type GraphQLError struct {
+	Message    string        `json:"message"`
+	Path       []interface{} `json:"path,omitempty"`
+	Extensions struct {
+		Code string `json:"code,omitempty"`
+	} `json:"extensions"`
+}
+
+// GraphQLErrors are the errors of a GraphQL response. The API can return
+// partial data along with the errors, which is discarded.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		message := err.Message
+		if err.Extensions.Code != "" {
+			message = fmt.Sprintf("%s (%s)", message, err.Extensions.Code)
+		}
+		messages = append(messages, message)
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// HasCode returns true if any of the errors has the given code, e.g. THROTTLED or ACCESS_DENIED
+func (e GraphQLErrors) HasCode(code string) bool {
+	for _, err := range e {
+		if err.Extensions.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// GraphQLThrottledError is returned when a query is still throttled after it has been retried
+type GraphQLThrottledError struct {
+	RequestedQueryCost int
+	ThrottleStatus     GraphQLThrottleStatus
+}
+
+func (e GraphQLThrottledError) Error() string {
+	return fmt.Sprintf("graphql: query cost %d exceeds the available budget of %.0f (maximum %.0f, restore rate %.0f/s)",
+		e.RequestedQueryCost, e.ThrottleStatus.CurrentlyAvailable, e.ThrottleStatus.MaximumAvailable, e.ThrottleStatus.RestoreRate)
+}
+
+func connectGraphQL(ctx context.Context, d *plugin.QueryData) (*GraphQLClient, error) {
+	// Load the client from cache, so that the connection shares one query cost budget
+	cacheKey := "shopify_graphql"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*GraphQLClient), nil
+	}
+
+	// The REST client resolves the credentials from the config and the env vars
+	conn, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// The REST client was created with the same version, so it can't be invalid here
+	apiVersion, _ := getAPIVersion(GetConfig(d.Connection))
+	if apiVersion == "" {
+		apiVersion = defaultGraphQLAPIVersion
+	}
+
+	client := &GraphQLClient{
+		rest:       conn,
+		path:       fmt.Sprintf("admin/api/%s/graphql.json", apiVersion),
+		queryCosts: map[string]int{},
+	}
+	d.ConnectionManager.Cache.Set(cacheKey, client)
+
+	return client, nil
+}
+
+// Query sends a query and decodes its `data` into v. Throttled queries are retried
+// once the budget has been restored.
+func (c *GraphQLClient) Query(ctx context.Context, query string, variables map[string]interface{}, v interface{}) (*GraphQLCost, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.waitForBudget(ctx, c.estimatedCost(query)); err != nil {
+			return nil, err
+		}
+
+		req, err := c.rest.NewRequest("POST", c.path, graphQLRequest{Query: query, Variables: variables}, nil)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+
+		result := new(graphQLResponse)
+		if err := c.rest.Do(req, result); err != nil {
+			return nil, err
+		}
+
+		cost := result.Extensions.Cost
+		c.updateCost(query, cost)
+
+		if len(result.Errors) > 0 {
+			errs := GraphQLErrors(result.Errors)
+			if errs.HasCode("THROTTLED") && cost != nil {
+				if attempt < graphQLMaxThrottleRetries {
+					plugin.Logger(ctx).Debug("GraphQLClient.Query", "throttled", cost.RequestedQueryCost, "attempt", attempt+1)
+					continue
+				}
+				throttled := GraphQLThrottledError{RequestedQueryCost: cost.RequestedQueryCost}
+				if cost.ThrottleStatus != nil {
+					throttled.ThrottleStatus = *cost.ThrottleStatus
+				}
+				return cost, throttled
+			}
+			return cost, errs
+		}
+
+		if v != nil {
+			if err := json.Unmarshal(result.Data, v); err != nil {
+				return cost, err
+			}
+		}
+		return cost, nil
+	}
+}
+
+// estimatedCost returns the cost the API reported the last time the query was sent
+func (c *GraphQLClient) estimatedCost(query string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queryCosts[query]
+}
+
+func (c *GraphQLClient) updateCost(query string, cost *GraphQLCost) {
+	if cost == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queryCosts[query] = cost.RequestedQueryCost
+	if cost.ThrottleStatus != nil {
+		status := *cost.ThrottleStatus
+		c.throttle = &status
+		c.throttleAt = time.Now()
+	}
+}
+
+// waitForBudget blocks until the budget has restored enough points for a query of the given cost
+func (c *GraphQLClient) waitForBudget(ctx context.Context, cost int) error {
+	c.mu.Lock()
+	var wait time.Duration
+	if c.throttle != nil && c.throttle.RestoreRate > 0 {
+		// the budget is restored continuously, up to the maximum
+		elapsed := time.Since(c.throttleAt).Seconds()
+		available := math.Min(c.throttle.MaximumAvailable, c.throttle.CurrentlyAvailable+elapsed*c.throttle.RestoreRate)
+		needed := math.Min(float64(cost), c.throttle.MaximumAvailable)
+		if needed > available {
+			wait = time.Duration((needed - available) / c.throttle.RestoreRate * float64(time.Second))
+		}
+	}
+	c.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	plugin.Logger(ctx).Debug("GraphQLClient.waitForBudget", "cost", cost, "wait", wait.String())
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// graphQLPageSize returns the page size for a connection, lowered to the limit of the query context
+func graphQLPageSize(d *plugin.QueryData, maxPageSize int) int {
+	pageSize := maxPageSize
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < int64(pageSize) {
+			pageSize = int(*limit)
+		}
+	}
+	return pageSize
+}
+
+// listGraphQLNodes pages through a connection and calls fn with each node until there
+// are no more pages or fn returns false. The query must take the `$first: Int!` and
+// `$after: String` variables, and select `nodes` and `pageInfo { hasNextPage endCursor }`
+// on the connection. path is the list of fields from `data` down to the connection.
+func listGraphQLNodes[T any](ctx context.Context, client *GraphQLClient, query string, variables map[string]interface{}, path []string, fn func(node T) bool) error {
+	vars := map[string]interface{}{}
+	for k, v := range variables {
+		vars[k] = v
+	}
+	if _, ok := vars["first"]; !ok {
+		vars["first"] = graphQLMaxPageSize
+	}
+
+	for {
+		var data json.RawMessage
+		if _, err := client.Query(ctx, query, vars, &data); err != nil {
+			return err
+		}
+
+		connection := new(GraphQLConnection[T])
+		found, err := decodeGraphQLPath(data, path, connection)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return nil
+		}
+
+		for _, node := range connection.Nodes {
+			if !fn(node) {
+				return nil
+			}
+		}
+		for _, edge := range connection.Edges {
+			if !fn(edge.Node) {
+				return nil
+			}
+		}
+
+		if !connection.PageInfo.HasNextPage {
+			return nil
+		}
+		vars["after"] = connection.PageInfo.EndCursor
+	}
+}
+
+// decodeGraphQLPath follows path down from data and decodes the value at the end into v.
+// It returns false if a field along the path is null, e.g. when the parent resource doesn't exist.
+func decodeGraphQLPath(data json.RawMessage, path []string, v interface{}) (bool, error) {
+	for _, field := range path {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return false, err
+		}
+		if fields == nil {
+			return false, nil
+		}
+		value, ok := fields[field]
+		if !ok {
+			return false, errors.New("graphql: field " + field + " is missing from the response")
+		}
+		data = value
+	}
+	if string(data) == "null" {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// parseGID returns the numeric ID of a GraphQL global ID, e.g. 123 for gid://shopify/Order/123
+// or gid://shopify/MailingAddress/123?model_name=CustomerAddress
+func parseGID(gid string) int64 {
+	gid, _, _ = strings.Cut(gid, "?")
+	id, _ := strconv.ParseInt(gid[strings.LastIndex(gid, "/")+1:], 10, 64)
+	return id
+}
+
+// graphQLMoneyBag is an amount in the shop and the presentment currencies
+type graphQLMoneyBag struct {
+	ShopMoney struct {
+		Amount *decimal.Decimal `json:"amount"`
+	} `json:"shopMoney"`
+}
+
+// amount returns the amount in the shop currency
+func (m *graphQLMoneyBag) amount() *decimal.Decimal {
+	if m == nil {
+		return nil
+	}
+	return m.ShopMoney.Amount
+}
+
+const graphQLMailingAddressFields = `id
+address1
+address2
+city
+company
+country
+countryCodeV2
+firstName
+lastName
+latitude
+longitude
+name
+phone
+province
+provinceCode
+zip`
+
+// graphQLMailingAddress is an address in the GraphQL API
+type graphQLMailingAddress struct {
+	ID           string  `json:"id"`
+	Address1     string  `json:"address1"`
+	Address2     string  `json:"address2"`
+	City         string  `json:"city"`
+	Company      string  `json:"company"`
+	Country      string  `json:"country"`
+	CountryCode  string  `json:"countryCodeV2"`
+	FirstName    string  `json:"firstName"`
+	LastName     string  `json:"lastName"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	Name         string  `json:"name"`
+	Phone        string  `json:"phone"`
+	Province     string  `json:"province"`
+	ProvinceCode string  `json:"provinceCode"`
+	Zip          string  `json:"zip"`
+}
+
+func (a *graphQLMailingAddress) toAddress() *goshopify.Address {
+	if a == nil {
+		return nil
+	}
+	return &goshopify.Address{
+		ID:           parseGID(a.ID),
+		Address1:     a.Address1,
+		Address2:     a.Address2,
+		City:         a.City,
+		Company:      a.Company,
+		Country:      a.Country,
+		CountryCode:  a.CountryCode,
+		FirstName:    a.FirstName,
+		LastName:     a.LastName,
+		Latitude:     a.Latitude,
+		Longitude:    a.Longitude,
+		Name:         a.Name,
+		Phone:        a.Phone,
+		Province:     a.Province,
+		ProvinceCode: a.ProvinceCode,
+		Zip:          a.Zip,
+	}
+}
+
+func (a *graphQLMailingAddress) toCustomerAddress(customerID int64) *goshopify.CustomerAddress {
+	if a == nil {
+		return nil
+	}
+	return &goshopify.CustomerAddress{
+		ID:           parseGID(a.ID),
+		CustomerID:   customerID,
+		FirstName:    a.FirstName,
+		LastName:     a.LastName,
+		Company:      a.Company,
+		Address1:     a.Address1,
+		Address2:     a.Address2,
+		City:         a.City,
+		Province:     a.Province,
+		Country:      a.Country,
+		Zip:          a.Zip,
+		Phone:        a.Phone,
+		Name:         a.Name,
+		ProvinceCode: a.ProvinceCode,
+		CountryCode:  a.CountryCode,
+		CountryName:  a.Country,
+	}
+}
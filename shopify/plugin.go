@@ -27,15 +27,49 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			},
 		},
 		TableMap: map[string]*plugin.Table{
-			"shopify_collection_product": tableShopifyCollectionProduct(ctx),
-			"shopify_custom_collection":  tableShopifyCustomCollection(ctx),
-			"shopify_customer":           tableShopifyCustomer(ctx),
-			"shopify_draft_order":        tableShopifyDraftOrder(ctx),
-			"shopify_order":              tableShopifyOrder(ctx),
-			"shopify_product":            tableShopifyProduct(ctx),
-			"shopify_product_variant":    tableShopifyProductVariant(ctx),
-			"shopify_smart_collection":   tableShopifySmartCollection(ctx),
-			"shopify_theme":              tableShopifyTheme(ctx),
+			"shopify_application_charge":           tableShopifyApplicationCharge(ctx),
+			"shopify_balance_transaction":          tableShopifyBalanceTransaction(ctx),
+			"shopify_carrier_service":              tableShopifyCarrierService(ctx),
+			"shopify_collection_product":           tableShopifyCollectionProduct(ctx),
+			"shopify_company":                      tableShopifyCompany(ctx),
+			"shopify_company_contact":              tableShopifyCompanyContact(ctx),
+			"shopify_company_location":             tableShopifyCompanyLocation(ctx),
+			"shopify_country":                      tableShopifyCountry(ctx),
+			"shopify_custom_collection":            tableShopifyCustomCollection(ctx),
+			"shopify_customer":                     tableShopifyCustomer(ctx),
+			"shopify_customer_address":             tableShopifyCustomerAddress(ctx),
+			"shopify_customer_saved_search":        tableShopifyCustomerSavedSearch(ctx),
+			"shopify_customer_saved_search_member": tableShopifyCustomerSavedSearchMember(ctx),
+			"shopify_customer_segment":             tableShopifyCustomerSegment(ctx),
+			"shopify_customer_segment_member":      tableShopifyCustomerSegmentMember(ctx),
+			"shopify_dispute":                      tableShopifyDispute(ctx),
+			"shopify_draft_order":                  tableShopifyDraftOrder(ctx),
+			"shopify_event":                        tableShopifyEvent(ctx),
+			"shopify_file":                         tableShopifyFile(ctx),
+			"shopify_fulfillment_service":          tableShopifyFulfillmentService(ctx),
+			"shopify_graphql_query":                tableShopifyGraphQLQuery(ctx),
+			"shopify_market":                       tableShopifyMarket(ctx),
+			"shopify_marketing_event":              tableShopifyMarketingEvent(ctx),
+			"shopify_metafield":                    tableShopifyMetafield(ctx),
+			"shopify_metaobject":                   tableShopifyMetaobject(ctx),
+			"shopify_metaobject_definition":        tableShopifyMetaobjectDefinition(ctx),
+			"shopify_order":                        tableShopifyOrder(ctx),
+			"shopify_order_risk":                   tableShopifyOrderRisk(ctx),
+			"shopify_payout":                       tableShopifyPayout(ctx),
+			"shopify_price_list":                   tableShopifyPriceList(ctx),
+			"shopify_product":                      tableShopifyProduct(ctx),
+			"shopify_product_variant":              tableShopifyProductVariant(ctx),
+			"shopify_province":                     tableShopifyProvince(ctx),
+			"shopify_recurring_application_charge": tableShopifyRecurringApplicationCharge(ctx),
+			"shopify_return":                       tableShopifyReturn(ctx),
+			"shopify_selling_plan_group":           tableShopifySellingPlanGroup(ctx),
+			"shopify_shipping_zone":                tableShopifyShippingZone(ctx),
+			"shopify_smart_collection":             tableShopifySmartCollection(ctx),
+			"shopify_subscription_contract":        tableShopifySubscriptionContract(ctx),
+			"shopify_tender_transaction":           tableShopifyTenderTransaction(ctx),
+			"shopify_theme":                        tableShopifyTheme(ctx),
+			"shopify_usage_charge":                 tableShopifyUsageCharge(ctx),
+			"shopify_user":                         tableShopifyUser(ctx),
 		},
 	}
 	return p
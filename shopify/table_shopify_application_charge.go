@@ -0,0 +1,140 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableShopifyApplicationCharge(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_application_charge",
+		Description: "Shopify application charge is a one-time charge that an app makes to a merchant for a one-time purchase.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getApplicationCharge,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listApplicationCharges,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The application charge ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the application charge.",
+			},
+			{
+				Name:        "status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The status of the application charge. Possible values are: pending, accepted, active, declined and expired.",
+			},
+			{
+				Name:        "price",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The price of the application charge.",
+				Transform:   transform.FromField("Price").Transform(convertPrice),
+			},
+			{
+				Name:        "test",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the application charge is a test transaction.",
+			},
+			{
+				Name:        "charge_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the application charge.",
+			},
+			{
+				Name:        "api_client_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the app that created the application charge.",
+				Transform:   transform.FromField("APIClientID"),
+			},
+			{
+				Name:        "return_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The URL where the merchant is redirected after accepting the charge.",
+				Transform:   transform.FromField("ReturnURL"),
+			},
+			{
+				Name:        "confirmation_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The URL where the merchant accepts or declines the application charge.",
+				Transform:   transform.FromField("ConfirmationURL"),
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the application charge was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the application charge was last updated.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listApplicationCharges(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_application_charge.listApplicationCharges", "connection_error", err)
+		return nil, err
+	}
+
+	charges, err := conn.ApplicationCharge.List(nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_application_charge.listApplicationCharges", "api_error", err)
+		return nil, err
+	}
+
+	for _, charge := range charges {
+		d.StreamListItem(ctx, charge)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+func getApplicationCharge(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_application_charge.getApplicationCharge", "connection_error", err)
+		return nil, err
+	}
+
+	result, err := conn.ApplicationCharge.Get(id, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_application_charge.getApplicationCharge", "api_error", err)
+		return nil, err
+	}
+
+	return result, nil
+}
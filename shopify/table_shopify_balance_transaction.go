@@ -0,0 +1,195 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// BalanceTransaction represents a movement of money in the Shopify Payments balance.
+type BalanceTransaction struct {
+	ID                       int64      `json:"id,omitempty"`
+	Type                     string     `json:"type,omitempty"`
+	Test                     bool       `json:"test,omitempty"`
+	PayoutID                 int64      `json:"payout_id,omitempty"`
+	PayoutStatus             string     `json:"payout_status,omitempty"`
+	Currency                 string     `json:"currency,omitempty"`
+	Amount                   string     `json:"amount,omitempty"`
+	Fee                      string     `json:"fee,omitempty"`
+	Net                      string     `json:"net,omitempty"`
+	SourceID                 int64      `json:"source_id,omitempty"`
+	SourceType               string     `json:"source_type,omitempty"`
+	SourceOrderID            int64      `json:"source_order_id,omitempty"`
+	SourceOrderTransactionID int64      `json:"source_order_transaction_id,omitempty"`
+	ProcessedAt              *time.Time `json:"processed_at,omitempty"`
+}
+
+type BalanceTransactionsResource struct {
+	Transactions []BalanceTransaction `json:"transactions"`
+}
+
+// BalanceTransactionListOptions are the options for the balance transactions endpoint
+type BalanceTransactionListOptions struct {
+	PageInfo     string `url:"page_info,omitempty"`
+	Limit        int    `url:"limit,omitempty"`
+	PayoutID     int64  `url:"payout_id,omitempty"`
+	PayoutStatus string `url:"payout_status,omitempty"`
+}
+
+func tableShopifyBalanceTransaction(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_balance_transaction",
+		Description: "Shopify balance transaction is a movement of money in and out of the Shopify Payments balance, such as a charge, a refund or a payout.",
+		List: &plugin.ListConfig{
+			Hydrate: listBalanceTransactions,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "payout_id", Require: plugin.Optional},
+				{Name: "payout_status", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The balance transaction ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the balance transaction, e.g. charge, refund, dispute, reserve, adjustment, payout or payout_failure.",
+			},
+			{
+				Name:        "test",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the balance transaction is a test transaction.",
+			},
+			{
+				Name:        "currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 currency code of the balance transaction.",
+			},
+			{
+				Name:        "amount",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The gross amount of the balance transaction.",
+				Transform:   transform.FromField("Amount").Transform(transform.ToDouble),
+			},
+			{
+				Name:        "fee",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The amount of fees deducted from the balance transaction amount.",
+				Transform:   transform.FromField("Fee").Transform(transform.ToDouble),
+			},
+			{
+				Name:        "net",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The net amount of the balance transaction, after fees.",
+				Transform:   transform.FromField("Net").Transform(transform.ToDouble),
+			},
+			{
+				Name:        "payout_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the payout that the balance transaction was paid out in.",
+				Transform:   transform.FromField("PayoutID"),
+			},
+			{
+				Name:        "payout_status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The status of the payout that the balance transaction was paid out in, or pending if it isn't part of a payout yet.",
+			},
+			{
+				Name:        "source_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the resource that led to the balance transaction.",
+				Transform:   transform.FromField("SourceID"),
+			},
+			{
+				Name:        "source_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the resource that led to the balance transaction, e.g. charge, refund, dispute, reserve, adjustment or payout.",
+			},
+			{
+				Name:        "source_order_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the order that the balance transaction belongs to.",
+				Transform:   transform.FromField("SourceOrderID"),
+			},
+			{
+				Name:        "source_order_transaction_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the order transaction that the balance transaction belongs to.",
+				Transform:   transform.FromField("SourceOrderTransactionID"),
+			},
+			{
+				Name:        "processed_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the balance transaction was processed.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("ID").Transform(transform.ToString),
+			},
+		}),
+	}
+}
+
+func listBalanceTransactions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_balance_transaction.listBalanceTransactions", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := BalanceTransactionListOptions{
+		Limit:        250,
+		PayoutID:     d.EqualsQuals["payout_id"].GetInt64Value(),
+		PayoutStatus: d.EqualsQualString("payout_status"),
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < 250 {
+			options.Limit = int(*limit)
+		}
+	}
+
+	for {
+		result := new(BalanceTransactionsResource)
+		paginator, err := conn.ListWithPagination("shopify_payments/balance/transactions.json", result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_balance_transaction.listBalanceTransactions", "api_error", err)
+			if isUnavailableError(err) {
+				return nil, fmt.Errorf("%s: %v", paymentsUnavailableMessage, err)
+			}
+			return nil, err
+		}
+
+		for _, transaction := range result.Transactions {
+			d.StreamListItem(ctx, transaction)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		// The filters are encoded in the page_info cursor and can't be passed again
+		options = BalanceTransactionListOptions{
+			PageInfo: paginator.NextPageOptions.PageInfo,
+			Limit:    options.Limit,
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableShopifyCarrierService(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_carrier_service",
+		Description: "Shopify carrier service is an external service that provides calculated shipping rates at checkout.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getCarrierService,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCarrierServices,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The carrier service ID.",
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the shipping service as seen by merchants and their customers.",
+			},
+			{
+				Name:        "active",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the carrier service is active.",
+			},
+			{
+				Name:        "callback_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The URL endpoint that Shopify calls to retrieve shipping rates.",
+				Transform:   transform.FromField("CallbackUrl"),
+			},
+			{
+				Name:        "carrier_service_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "Distinguishes between API or legacy carrier services.",
+			},
+			{
+				Name:        "format",
+				Type:        proto.ColumnType_STRING,
+				Description: "The format of the data returned by the callback URL. Possible values are: json and xml.",
+			},
+			{
+				Name:        "service_discovery",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether merchants are able to send dummy data to the service through the Shopify admin to see shipping rate examples.",
+			},
+			{
+				Name:        "admin_graphql_api_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The admin graphql API ID of the carrier service.",
+				Transform:   transform.FromField("AdminGraphqlAPIID"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listCarrierServices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_carrier_service.listCarrierServices", "connection_error", err)
+		return nil, err
+	}
+
+	carriers, err := conn.CarrierService.List()
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_carrier_service.listCarrierServices", "api_error", err)
+		return nil, err
+	}
+
+	for _, carrier := range carriers {
+		d.StreamListItem(ctx, carrier)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+func getCarrierService(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_carrier_service.getCarrierService", "connection_error", err)
+		return nil, err
+	}
+
+	result, err := conn.CarrierService.Get(id)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_carrier_service.getCarrierService", "api_error", err)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,239 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Company represents a business customer that buys from the store through B2B.
+type Company struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	ExternalID     string        `json:"externalId"`
+	Note           string        `json:"note"`
+	CustomerSince  *time.Time    `json:"customerSince"`
+	CreatedAt      *time.Time    `json:"createdAt"`
+	UpdatedAt      *time.Time    `json:"updatedAt"`
+	OrdersCount    *graphQLCount `json:"ordersCount"`
+	ContactsCount  *graphQLCount `json:"contactsCount"`
+	LocationsCount *graphQLCount `json:"locationsCount"`
+	TotalSpent     *graphQLMoney `json:"totalSpent"`
+	MainContact    *struct {
+		ID       string `json:"id"`
+		Customer *struct {
+			ID string `json:"id"`
+		} `json:"customer"`
+	} `json:"mainContact"`
+}
+
+const companyFields = `id
+name
+externalId
+note
+customerSince
+createdAt
+updatedAt
+ordersCount {
+  count
+}
+contactsCount {
+  count
+}
+locationsCount {
+  count
+}
+totalSpent {
+  amount
+  currencyCode
+}
+mainContact {
+  id
+  customer {
+    id
+  }
+}`
+
+const listCompaniesQuery = `query($first: Int!, $after: String) {
+  companies(first: $first, after: $after) {
+    nodes {
+      ` + companyFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getCompanyQuery = `query($id: ID!) {
+  company(id: $id) {
+    ` + companyFields + `
+  }
+}`
+
+// Each company costs 7 points: the company, its 3 counts, its total spent, and its main
+// contact with the customer. 100 of them cost 2+100*7 = 702 points, below the max of 1000
+// points of a query.
+const companyPageSize = 100
+
+func tableShopifyCompany(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_company",
+		Description: "Shopify company is a business customer that buys from the store through B2B, with its own locations, contacts and payment terms.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getCompany,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCompanies,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the company.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the company.",
+			},
+			{
+				Name:        "external_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the company in an external system, e.g. an ERP.",
+				Transform:   transform.FromField("ExternalID"),
+			},
+			{
+				Name:        "note",
+				Type:        proto.ColumnType_STRING,
+				Description: "A note about the company.",
+			},
+			{
+				Name:        "main_contact_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the main contact of the company.",
+				Transform:   transform.FromField("MainContact.ID"),
+			},
+			{
+				Name:        "main_contact_customer_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the customer of the main contact of the company.",
+				Transform:   transform.FromField("MainContact.Customer.ID").Transform(gidToID),
+			},
+			{
+				Name:        "orders_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of orders placed by the company.",
+				Transform:   transform.FromField("OrdersCount.Count"),
+			},
+			{
+				Name:        "total_spent",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The total amount spent by the company.",
+				Transform:   transform.FromField("TotalSpent.Amount").Transform(convertPrice),
+			},
+			{
+				Name:        "total_spent_currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 code of the currency of the total amount spent.",
+				Transform:   transform.FromField("TotalSpent.CurrencyCode"),
+			},
+			{
+				Name:        "contacts_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of contacts of the company.",
+				Transform:   transform.FromField("ContactsCount.Count"),
+			},
+			{
+				Name:        "locations_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of locations of the company.",
+				Transform:   transform.FromField("LocationsCount.Count"),
+			},
+			{
+				Name:        "customer_since",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the company became a customer.",
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the company was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the company was last updated.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listCompanies(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company.listCompanies", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"first": graphQLPageSize(d, companyPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listCompaniesQuery, variables, []string{"companies"}, func(company Company) bool {
+		d.StreamListItem(ctx, company)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company.listCompanies", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getCompany(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company.getCompany", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		Company *Company `json:"company"`
+	}
+	_, err = client.Query(ctx, getCompanyQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company.getCompany", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the company doesn't exist
+	if result.Company == nil {
+		return nil, nil
+	}
+
+	return *result.Company, nil
+}
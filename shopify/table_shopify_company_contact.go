@@ -0,0 +1,263 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// CompanyContact represents a customer that buys on behalf of a company.
+type CompanyContact struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Locale        string     `json:"locale"`
+	IsMainContact bool       `json:"isMainContact"`
+	CreatedAt     *time.Time `json:"createdAt"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+	Company       *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"company"`
+	Customer *struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+		Email       string `json:"email"`
+	} `json:"customer"`
+}
+
+// CompanyContactRoleAssignment represents the role of a contact at a location of the company.
+type CompanyContactRoleAssignment struct {
+	ID   string `json:"id"`
+	Role *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"role"`
+	CompanyLocation *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"companyLocation"`
+}
+
+const listCompanyContactsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  company(id: $id) {
+    contacts(first: $first, after: $after) {
+      nodes {
+        id
+        title
+        locale
+        isMainContact
+        createdAt
+        updatedAt
+        company {
+          id
+          name
+        }
+        customer {
+          id
+          displayName
+          email
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const listCompanyContactRoleAssignmentsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  companyContact(id: $id) {
+    roleAssignments(first: $first, after: $after) {
+      nodes {
+        id
+        role {
+          id
+          name
+        }
+        companyLocation {
+          id
+          name
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+func tableShopifyCompanyContact(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_company_contact",
+		Description: "Shopify company contact is a customer that buys on behalf of a B2B company, with roles at the locations of the company.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listCompanyContactCompanies,
+			Hydrate:       listCompanyContacts,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "company_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the company contact.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "company_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the company the contact belongs to.",
+				Transform:   transform.FromField("Company.ID"),
+			},
+			{
+				Name:        "company_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the company the contact belongs to.",
+				Transform:   transform.FromField("Company.Name"),
+			},
+			{
+				Name:        "customer_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the customer of the contact.",
+				Transform:   transform.FromField("Customer.ID").Transform(gidToID),
+			},
+			{
+				Name:        "customer_display_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The full name of the customer of the contact.",
+				Transform:   transform.FromField("Customer.DisplayName"),
+			},
+			{
+				Name:        "email",
+				Type:        proto.ColumnType_STRING,
+				Description: "The email address of the customer of the contact.",
+				Transform:   transform.FromField("Customer.Email"),
+			},
+			{
+				Name:        "job_title",
+				Type:        proto.ColumnType_STRING,
+				Description: "The job title of the contact at the company.",
+				Transform:   transform.FromField("Title"),
+			},
+			{
+				Name:        "locale",
+				Type:        proto.ColumnType_STRING,
+				Description: "The preferred locale of the contact.",
+			},
+			{
+				Name:        "is_main_contact",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the contact is the main contact of the company.",
+			},
+			{
+				Name:        "role_assignments",
+				Type:        proto.ColumnType_JSON,
+				Description: "The roles of the contact at the locations of the company, e.g. Ordering only or Location admin.",
+				Hydrate:     listCompanyContactRoleAssignments,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the company contact was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the company contact was last updated.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Customer.DisplayName"),
+			},
+		}),
+	}
+}
+
+func listCompanyContactCompanies(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Skip listing the companies if the company is known
+	if companyID := d.EqualsQualString("company_id"); companyID != "" {
+		d.StreamListItem(ctx, Company{ID: companyID})
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_contact.listCompanyContactCompanies", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"first": companyPageSize,
+	}
+	err = listGraphQLNodes(ctx, client, listCompaniesQuery, variables, []string{"companies"}, func(company Company) bool {
+		d.StreamListItem(ctx, company)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_contact.listCompanyContactCompanies", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func listCompanyContacts(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	company := h.Item.(Company)
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_contact.listCompanyContacts", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"id":    company.ID,
+		"first": graphQLPageSize(d, graphQLMaxPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listCompanyContactsQuery, variables, []string{"company", "contacts"}, func(contact CompanyContact) bool {
+		d.StreamListItem(ctx, contact)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_contact.listCompanyContacts", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func listCompanyContactRoleAssignments(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(CompanyContact).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_contact.listCompanyContactRoleAssignments", "connection_error", err)
+		return nil, err
+	}
+
+	var assignments []CompanyContactRoleAssignment
+	err = listGraphQLNodes(ctx, client, listCompanyContactRoleAssignmentsQuery, map[string]interface{}{"id": id}, []string{"companyContact", "roleAssignments"}, func(assignment CompanyContactRoleAssignment) bool {
+		assignments = append(assignments, assignment)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_contact.listCompanyContactRoleAssignments", "api_error", err)
+		return nil, err
+	}
+
+	return assignments, nil
+}
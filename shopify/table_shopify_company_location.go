@@ -0,0 +1,404 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// CompanyLocation represents a branch or an office of a company that places B2B orders.
+type CompanyLocation struct {
+	ID                string        `json:"id"`
+	Name              string        `json:"name"`
+	ExternalID        string        `json:"externalId"`
+	Note              string        `json:"note"`
+	Phone             string        `json:"phone"`
+	Locale            string        `json:"locale"`
+	Currency          string        `json:"currency"`
+	TaxRegistrationID string        `json:"taxRegistrationId"`
+	TaxExemptions     []string      `json:"taxExemptions"`
+	CreatedAt         *time.Time    `json:"createdAt"`
+	UpdatedAt         *time.Time    `json:"updatedAt"`
+	OrdersCount       *graphQLCount `json:"ordersCount"`
+	TotalSpent        *graphQLMoney `json:"totalSpent"`
+	Company           *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"company"`
+	BuyerExperienceConfiguration *struct {
+		CheckoutToDraft         bool `json:"checkoutToDraft"`
+		EditableShippingAddress bool `json:"editableShippingAddress"`
+		PaymentTermsTemplate    *struct {
+			ID               string `json:"id"`
+			Name             string `json:"name"`
+			PaymentTermsType string `json:"paymentTermsType"`
+			DueInDays        *int   `json:"dueInDays"`
+		} `json:"paymentTermsTemplate"`
+	} `json:"buyerExperienceConfiguration"`
+	BillingAddress  interface{} `json:"billingAddress"`
+	ShippingAddress interface{} `json:"shippingAddress"`
+}
+
+// CompanyLocationCatalog represents a catalog a company location has access to.
+type CompanyLocationCatalog struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+const companyAddressFields = `id
+recipient
+firstName
+lastName
+companyName
+address1
+address2
+city
+province
+zoneCode
+zip
+country
+countryCode
+phone`
+
+const companyLocationFields = `id
+name
+externalId
+note
+phone
+locale
+currency
+taxRegistrationId
+taxExemptions
+createdAt
+updatedAt
+ordersCount {
+  count
+}
+totalSpent {
+  amount
+  currencyCode
+}
+company {
+  id
+  name
+}
+buyerExperienceConfiguration {
+  checkoutToDraft
+  editableShippingAddress
+  paymentTermsTemplate {
+    id
+    name
+    paymentTermsType
+    dueInDays
+  }
+}
+billingAddress {
+  ` + companyAddressFields + `
+}
+shippingAddress {
+  ` + companyAddressFields + `
+}`
+
+const listCompanyLocationsQuery = `query($first: Int!, $after: String) {
+  companyLocations(first: $first, after: $after) {
+    nodes {
+      ` + companyLocationFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const listCompanyLocationsByCompanyQuery = `query($id: ID!, $first: Int!, $after: String) {
+  company(id: $id) {
+    locations(first: $first, after: $after) {
+      nodes {
+        ` + companyLocationFields + `
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const getCompanyLocationQuery = `query($id: ID!) {
+  companyLocation(id: $id) {
+    ` + companyLocationFields + `
+  }
+}`
+
+const listCompanyLocationCatalogsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  companyLocation(id: $id) {
+    catalogs(first: $first, after: $after) {
+      nodes {
+        id
+        title
+        status
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// Each location costs 8 points: the location, its orders count, its total spent, its
+// company, its buyer experience configuration with the payment terms template, and its 2
+// addresses. 100 of them cost 2+100*8+1 = 803 points with the company of the company_id
+// qual, below the max of 1000 points of a query.
+const companyLocationPageSize = 100
+
+func tableShopifyCompanyLocation(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_company_location",
+		Description: "Shopify company location is a branch or an office of a B2B company, with its own addresses, payment terms, catalogs and tax settings.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getCompanyLocation,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCompanyLocations,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "company_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the company location.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the company location.",
+			},
+			{
+				Name:        "company_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the company the location belongs to.",
+				Transform:   transform.FromField("Company.ID"),
+			},
+			{
+				Name:        "company_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the company the location belongs to.",
+				Transform:   transform.FromField("Company.Name"),
+			},
+			{
+				Name:        "external_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the company location in an external system, e.g. an ERP.",
+				Transform:   transform.FromField("ExternalID"),
+			},
+			{
+				Name:        "note",
+				Type:        proto.ColumnType_STRING,
+				Description: "A note about the company location.",
+			},
+			{
+				Name:        "phone",
+				Type:        proto.ColumnType_STRING,
+				Description: "The phone number of the company location.",
+			},
+			{
+				Name:        "locale",
+				Type:        proto.ColumnType_STRING,
+				Description: "The preferred locale of the company location.",
+			},
+			{
+				Name:        "currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 code of the currency the company location buys in.",
+			},
+			{
+				Name:        "orders_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of orders placed for the company location.",
+				Transform:   transform.FromField("OrdersCount.Count"),
+			},
+			{
+				Name:        "total_spent",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The total amount spent by the company location.",
+				Transform:   transform.FromField("TotalSpent.Amount").Transform(convertPrice),
+			},
+			{
+				Name:        "total_spent_currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 code of the currency of the total amount spent.",
+				Transform:   transform.FromField("TotalSpent.CurrencyCode"),
+			},
+			{
+				Name:        "payment_terms_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the payment terms of the company location, e.g. Net 30.",
+				Transform:   transform.FromField("BuyerExperienceConfiguration.PaymentTermsTemplate.Name"),
+			},
+			{
+				Name:        "payment_terms_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the payment terms of the company location, e.g. net, fixed or receipt.",
+				Transform:   transform.FromField("BuyerExperienceConfiguration.PaymentTermsTemplate.PaymentTermsType").Transform(transform.ToLower),
+			},
+			{
+				Name:        "payment_terms_due_in_days",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of days after which the payment is due under the payment terms of the company location.",
+				Transform:   transform.FromField("BuyerExperienceConfiguration.PaymentTermsTemplate.DueInDays"),
+			},
+			{
+				Name:        "checkout_to_draft",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether checkouts of the company location are submitted as draft orders for review.",
+				Transform:   transform.FromField("BuyerExperienceConfiguration.CheckoutToDraft"),
+			},
+			{
+				Name:        "editable_shipping_address",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the buyers can enter a shipping address other than the one of the company location at checkout.",
+				Transform:   transform.FromField("BuyerExperienceConfiguration.EditableShippingAddress"),
+			},
+			{
+				Name:        "tax_registration_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The tax registration ID of the company location.",
+				Transform:   transform.FromField("TaxRegistrationID"),
+			},
+			{
+				Name:        "tax_exemptions",
+				Type:        proto.ColumnType_JSON,
+				Description: "The tax exemptions applied to the company location, e.g. CA_STATUS_CARD_EXEMPTION.",
+			},
+			{
+				Name:        "catalogs",
+				Type:        proto.ColumnType_JSON,
+				Description: "The catalogs the company location has access to.",
+				Hydrate:     listCompanyLocationCatalogs,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "billing_address",
+				Type:        proto.ColumnType_JSON,
+				Description: "The billing address of the company location.",
+			},
+			{
+				Name:        "shipping_address",
+				Type:        proto.ColumnType_JSON,
+				Description: "The shipping address of the company location.",
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the company location was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the company location was last updated.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listCompanyLocations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_location.listCompanyLocations", "connection_error", err)
+		return nil, err
+	}
+
+	query, path := listCompanyLocationsQuery, []string{"companyLocations"}
+	variables := map[string]interface{}{
+		"first": graphQLPageSize(d, companyLocationPageSize),
+	}
+
+	// List the locations of the company only if the company is known
+	if companyID := d.EqualsQualString("company_id"); companyID != "" {
+		query, path = listCompanyLocationsByCompanyQuery, []string{"company", "locations"}
+		variables["id"] = companyID
+	}
+	err = listGraphQLNodes(ctx, client, query, variables, path, func(location CompanyLocation) bool {
+		d.StreamListItem(ctx, location)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_location.listCompanyLocations", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getCompanyLocation(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_location.getCompanyLocation", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		CompanyLocation *CompanyLocation `json:"companyLocation"`
+	}
+	_, err = client.Query(ctx, getCompanyLocationQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_location.getCompanyLocation", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the company location doesn't exist
+	if result.CompanyLocation == nil {
+		return nil, nil
+	}
+
+	return *result.CompanyLocation, nil
+}
+
+func listCompanyLocationCatalogs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(CompanyLocation).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_location.listCompanyLocationCatalogs", "connection_error", err)
+		return nil, err
+	}
+
+	var catalogs []CompanyLocationCatalog
+	err = listGraphQLNodes(ctx, client, listCompanyLocationCatalogsQuery, map[string]interface{}{"id": id}, []string{"companyLocation", "catalogs"}, func(catalog CompanyLocationCatalog) bool {
+		catalogs = append(catalogs, catalog)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_company_location.listCompanyLocationCatalogs", "api_error", err)
+		return nil, err
+	}
+
+	return catalogs, nil
+}
@@ -0,0 +1,126 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// The go-shopify client doesn't have a service for countries, but the
+// shipping country and province types match the countries endpoint.
+type CountryResource struct {
+	Country *goshopify.ShippingCountry `json:"country"`
+}
+
+type CountriesResource struct {
+	Countries []goshopify.ShippingCountry `json:"countries"`
+}
+
+func tableShopifyCountry(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_country",
+		Description: "Shopify country is a country that the store ships to, with the tax rate that is applied to orders shipped there.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getCountry,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCountries,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The country ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The full name of the country in English.",
+			},
+			{
+				Name:        "code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The two-letter ISO 3166-1 alpha-2 code of the country. The code * represents the rest of the world.",
+			},
+			{
+				Name:        "tax",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The national sales tax rate applied to orders made by customers from the country.",
+				Transform:   transform.FromField("Tax").Transform(convertPrice),
+			},
+			{
+				Name:        "tax_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the tax for the country.",
+			},
+			{
+				Name:        "provinces",
+				Type:        proto.ColumnType_JSON,
+				Description: "The sub-regions of the country, such as states or provinces, with their tax settings.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listCountries(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_country.listCountries", "connection_error", err)
+		return nil, err
+	}
+
+	result := new(CountriesResource)
+	err = conn.Get("countries.json", result, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_country.listCountries", "api_error", err)
+		return nil, err
+	}
+
+	for _, country := range result.Countries {
+		d.StreamListItem(ctx, country)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+func getCountry(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_country.getCountry", "connection_error", err)
+		return nil, err
+	}
+
+	result := new(CountryResource)
+	err = conn.Get(fmt.Sprintf("countries/%d.json", id), result, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_country.getCountry", "api_error", err)
+		return nil, err
+	}
+
+	return result.Country, nil
+}
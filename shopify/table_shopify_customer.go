@@ -2,8 +2,14 @@ package shopify
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/shopspring/decimal"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -19,6 +25,14 @@ func tableShopifyCustomer(ctx context.Context) *plugin.Table {
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listCustomers,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "email", Require: plugin.Optional},
+				{Name: "phone", Require: plugin.Optional},
+				{Name: "state", Require: plugin.Optional},
+				{Name: "tags", Require: plugin.Optional},
+				{Name: "accepts_marketing", Require: plugin.Optional},
+				{Name: "query", Require: plugin.Optional},
+			},
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -130,6 +144,19 @@ func tableShopifyCustomer(ctx context.Context) *plugin.Table {
 				Transform:   transform.FromJSONTag(),
 				Description: "Customer metafields.",
 			},
+			{
+				Name:        "orders",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     listCustomerOrders,
+				Transform:   transform.FromValue(),
+				Description: "The orders placed by the customer, with their IDs and totals.",
+			},
+			{
+				Name:        "query",
+				Type:        proto.ColumnType_STRING,
+				Description: "A Shopify customer search query, e.g. 'country:Canada orders_count:>5'. If set, the customers are listed using the customer search API.",
+				Transform:   transform.FromQual("query"),
+			},
 			// Steampipe standard columns
 			{
 				Name:        "title",
@@ -141,6 +168,15 @@ func tableShopifyCustomer(ctx context.Context) *plugin.Table {
 	}
 }
 
+// customerSearchOptions are the options for the customers/search endpoint.
+// The search query can only be passed on the first page, later pages are
+// fetched using the page_info cursor alone.
+type customerSearchOptions struct {
+	PageInfo string `url:"page_info,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	Query    string `url:"query,omitempty"`
+}
+
 func listCustomers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	conn, err := connect(ctx, d)
 	if err != nil {
@@ -160,8 +196,29 @@ func listCustomers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 		}
 	}
 
+	// Use the customer search API if any of the search quals are passed
+	query := buildCustomerSearchQuery(d)
+
+	// Read the whole table with a bulk operation if they are enabled in the connection config
+	if query == "" && useBulkOperations(d) {
+		return listCustomersBulk(ctx, d)
+	}
+
+	searchOptions := customerSearchOptions{
+		Limit: options.Limit,
+		Query: query,
+	}
+
 	for {
-		customers, paginator, err := conn.Customer.ListWithPagination(options)
+		var customers []goshopify.Customer
+		var paginator *goshopify.Pagination
+		if query != "" {
+			result := new(goshopify.CustomersResource)
+			paginator, err = conn.ListWithPagination("customers/search.json", result, searchOptions)
+			customers = result.Customers
+		} else {
+			customers, paginator, err = conn.Customer.ListWithPagination(options)
+		}
 		if err != nil {
 			plugin.Logger(ctx).Error("shopify_customer.listCustomers", "api_error", err)
 			return nil, err
@@ -180,7 +237,50 @@ func listCustomers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 			return nil, nil
 		}
 		options.PageInfo = paginator.NextPageOptions.PageInfo
+		searchOptions.PageInfo = paginator.NextPageOptions.PageInfo
+		searchOptions.Query = ""
+	}
+}
+
+// buildCustomerSearchQuery builds a Shopify customer search query from the quals.
+// See https://shopify.dev/docs/api/admin-rest/latest/resources/customer#get-customers-search
+func buildCustomerSearchQuery(d *plugin.QueryData) string {
+	var terms []string
+
+	if d.EqualsQualString("query") != "" {
+		terms = append(terms, d.EqualsQualString("query"))
+	}
+
+	for _, column := range []string{"email", "phone", "state"} {
+		if value := d.EqualsQualString(column); value != "" {
+			terms = append(terms, fmt.Sprintf("%s:%s", column, quoteSearchValue(value)))
+		}
+	}
+
+	// The tags column is a comma separated list, so search for each tag separately
+	if value := d.EqualsQualString("tags"); value != "" {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag != "" {
+				terms = append(terms, fmt.Sprintf("tag:%s", quoteSearchValue(tag)))
+			}
+		}
 	}
+
+	if d.EqualsQuals["accepts_marketing"] != nil {
+		terms = append(terms, fmt.Sprintf("accepts_marketing:%t", d.EqualsQuals["accepts_marketing"].GetBoolValue()))
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// quoteSearchValue wraps a search value in quotes if it contains characters
+// that are part of the Shopify search syntax.
+func quoteSearchValue(value string) string {
+	if strings.ContainsAny(value, " :\"()") {
+		return fmt.Sprintf("\"%s\"", strings.ReplaceAll(value, "\"", "\\\""))
+	}
+	return value
 }
 
 func getCustomer(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
@@ -205,3 +305,209 @@ func getCustomer(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 
 	return result, nil
 }
+
+// CustomerOrder is the summary of an order returned in the orders column.
+type CustomerOrder struct {
+	ID                int64            `json:"id"`
+	Name              string           `json:"name"`
+	Currency          string           `json:"currency"`
+	TotalPrice        *decimal.Decimal `json:"total_price"`
+	FinancialStatus   string           `json:"financial_status"`
+	FulfillmentStatus string           `json:"fulfillment_status"`
+	CreatedAt         *time.Time       `json:"created_at"`
+}
+
+func listCustomerOrders(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	var id int64
+	switch item := h.Item.(type) {
+	case goshopify.Customer:
+		id = item.ID
+	case *goshopify.Customer:
+		id = item.ID
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer.listCustomerOrders", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := goshopify.OrderListOptions{
+		ListOptions: goshopify.ListOptions{
+			Limit:  250,
+			Fields: "id,name,currency,total_price,financial_status,fulfillment_status,created_at",
+		},
+		Status: "any",
+	}
+
+	orders := []CustomerOrder{}
+	path := fmt.Sprintf("customers/%d/orders.json", id)
+	for {
+		result := new(goshopify.OrdersResource)
+		paginator, err := conn.ListWithPagination(path, result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_customer.listCustomerOrders", "api_error", err)
+			return nil, err
+		}
+
+		for _, order := range result.Orders {
+			orders = append(orders, CustomerOrder{
+				ID:                order.ID,
+				Name:              order.Name,
+				Currency:          order.Currency,
+				TotalPrice:        order.TotalPrice,
+				FinancialStatus:   order.FinancialStatus,
+				FulfillmentStatus: order.FulfillmentStatus,
+				CreatedAt:         order.CreatedAt,
+			})
+		}
+
+		if paginator.NextPageOptions == nil {
+			return orders, nil
+		}
+		// Only the limit and fields can be passed along with the page_info cursor
+		options = goshopify.OrderListOptions{
+			ListOptions: goshopify.ListOptions{
+				PageInfo: paginator.NextPageOptions.PageInfo,
+				Limit:    options.Limit,
+				Fields:   options.Fields,
+			},
+		}
+	}
+}
+
+// bulkCustomersQuery selects the customers for a bulk operation
+const bulkCustomersQuery = `{
+  customers {
+    edges {
+      node {
+        id
+        email
+        phone
+        firstName
+        lastName
+        state
+        note
+        verifiedEmail
+        multipassIdentifier
+        numberOfOrders
+        taxExempt
+        amountSpent {
+          amount
+        }
+        tags
+        lastOrder {
+          id
+          name
+        }
+        emailMarketingConsent {
+          marketingState
+        }
+        defaultAddress {
+          ` + graphQLMailingAddressFields + `
+        }
+        addresses {
+          ` + graphQLMailingAddressFields + `
+        }
+        createdAt
+        updatedAt
+      }
+    }
+  }
+}`
+
+// bulkCustomer is a customer in the result of a bulk operation
+type bulkCustomer struct {
+	ID                  string     `json:"id"`
+	Email               string     `json:"email"`
+	Phone               string     `json:"phone"`
+	FirstName           string     `json:"firstName"`
+	LastName            string     `json:"lastName"`
+	State               string     `json:"state"`
+	Note                string     `json:"note"`
+	VerifiedEmail       bool       `json:"verifiedEmail"`
+	MultipassIdentifier string     `json:"multipassIdentifier"`
+	NumberOfOrders      string     `json:"numberOfOrders"`
+	TaxExempt           bool       `json:"taxExempt"`
+	CreatedAt           *time.Time `json:"createdAt"`
+	UpdatedAt           *time.Time `json:"updatedAt"`
+	AmountSpent         *struct {
+		Amount *decimal.Decimal `json:"amount"`
+	} `json:"amountSpent"`
+	Tags      []string `json:"tags"`
+	LastOrder *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"lastOrder"`
+	EmailMarketingConsent *struct {
+		MarketingState string `json:"marketingState"`
+	} `json:"emailMarketingConsent"`
+	DefaultAddress *graphQLMailingAddress  `json:"defaultAddress"`
+	Addresses      []graphQLMailingAddress `json:"addresses"`
+}
+
+func (c bulkCustomer) toCustomer() goshopify.Customer {
+	customer := goshopify.Customer{
+		ID:                  parseGID(c.ID),
+		Email:               c.Email,
+		Phone:               c.Phone,
+		FirstName:           c.FirstName,
+		LastName:            c.LastName,
+		State:               strings.ToLower(c.State),
+		Note:                c.Note,
+		VerifiedEmail:       c.VerifiedEmail,
+		MultipassIdentifier: c.MultipassIdentifier,
+		TaxExempt:           c.TaxExempt,
+		Tags:                strings.Join(c.Tags, ", "),
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
+	}
+	customer.OrdersCount, _ = strconv.Atoi(c.NumberOfOrders)
+	if c.AmountSpent != nil {
+		customer.TotalSpent = c.AmountSpent.Amount
+	}
+	if c.LastOrder != nil {
+		customer.LastOrderId = parseGID(c.LastOrder.ID)
+		customer.LastOrderName = c.LastOrder.Name
+	}
+	if c.EmailMarketingConsent != nil {
+		customer.AcceptsMarketing = c.EmailMarketingConsent.MarketingState == "SUBSCRIBED"
+	}
+	if c.DefaultAddress != nil {
+		customer.DefaultAddress = c.DefaultAddress.toCustomerAddress(customer.ID)
+		customer.DefaultAddress.Default = true
+	}
+	for _, address := range c.Addresses {
+		customerAddress := address.toCustomerAddress(customer.ID)
+		customerAddress.Default = customer.DefaultAddress != nil && customerAddress.ID == customer.DefaultAddress.ID
+		customer.Addresses = append(customer.Addresses, customerAddress)
+	}
+	return customer
+}
+
+// listCustomersBulk lists the customers with a bulk operation
+func listCustomersBulk(ctx context.Context, d *plugin.QueryData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer.listCustomersBulk", "connection_error", err)
+		return nil, err
+	}
+
+	err = client.RunBulkQuery(ctx, bulkCustomersQuery, func(object json.RawMessage, _ string) (bool, error) {
+		var result bulkCustomer
+		if err := json.Unmarshal(object, &result); err != nil {
+			return false, err
+		}
+		d.StreamListItem(ctx, result.toCustomer())
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0, nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer.listCustomersBulk", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,170 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableShopifyCustomerAddress(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_customer_address",
+		Description: "Shopify customer address stores the mailing addresses of a shop's customers.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listCustomerAddressCustomers,
+			Hydrate:       listCustomerAddresses,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "customer_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The address ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "customer_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the customer the address belongs to.",
+				Transform:   transform.FromField("CustomerID"),
+			},
+			{
+				Name:        "default",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether this is the default address of the customer.",
+			},
+			{
+				Name:        "first_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The first name of the person at the address.",
+			},
+			{
+				Name:        "last_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The last name of the person at the address.",
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The full name of the person at the address.",
+			},
+			{
+				Name:        "company",
+				Type:        proto.ColumnType_STRING,
+				Description: "The company of the person at the address.",
+			},
+			{
+				Name:        "address1",
+				Type:        proto.ColumnType_STRING,
+				Description: "The first line of the address.",
+			},
+			{
+				Name:        "address2",
+				Type:        proto.ColumnType_STRING,
+				Description: "The second line of the address.",
+			},
+			{
+				Name:        "city",
+				Type:        proto.ColumnType_STRING,
+				Description: "The city of the address.",
+			},
+			{
+				Name:        "province",
+				Type:        proto.ColumnType_STRING,
+				Description: "The province or state name of the address.",
+			},
+			{
+				Name:        "province_code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The two-letter code for the province or state of the address.",
+			},
+			{
+				Name:        "country",
+				Type:        proto.ColumnType_STRING,
+				Description: "The country of the address.",
+			},
+			{
+				Name:        "country_code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The two-letter country code of the address.",
+			},
+			{
+				Name:        "country_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The normalized country name of the address.",
+			},
+			{
+				Name:        "zip",
+				Type:        proto.ColumnType_STRING,
+				Description: "The zip or postal code of the address.",
+			},
+			{
+				Name:        "phone",
+				Type:        proto.ColumnType_STRING,
+				Description: "The phone number at the address.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Address1"),
+			},
+		}),
+	}
+}
+
+func listCustomerAddressCustomers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	customerID := d.EqualsQuals["customer_id"].GetInt64Value()
+
+	// List every customer if the customer isn't known
+	if customerID == 0 {
+		return listCustomers(ctx, d, h)
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_address.listCustomerAddressCustomers", "connection_error", err)
+		return nil, err
+	}
+
+	customer, err := conn.Customer.Get(customerID, nil)
+	if err != nil {
+		// The customer doesn't exist, so it has no addresses
+		var responseError goshopify.ResponseError
+		if errors.As(err, &responseError) && responseError.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("shopify_customer_address.listCustomerAddressCustomers", "api_error", err)
+		return nil, err
+	}
+	d.StreamListItem(ctx, *customer)
+
+	return nil, nil
+}
+
+func listCustomerAddresses(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	customer := h.Item.(goshopify.Customer)
+
+	for _, address := range customer.Addresses {
+		if address == nil {
+			continue
+		}
+		if address.CustomerID == 0 {
+			address.CustomerID = customer.ID
+		}
+		d.StreamListItem(ctx, *address)
+
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
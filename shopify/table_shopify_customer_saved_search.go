@@ -0,0 +1,139 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// CustomerSavedSearch represents a Shopify customer saved search.
+type CustomerSavedSearch struct {
+	ID        int64      `json:"id,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Query     string     `json:"query,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type CustomerSavedSearchResource struct {
+	CustomerSavedSearch *CustomerSavedSearch `json:"customer_saved_search"`
+}
+
+type CustomerSavedSearchesResource struct {
+	CustomerSavedSearches []CustomerSavedSearch `json:"customer_saved_searches"`
+}
+
+func tableShopifyCustomerSavedSearch(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_customer_saved_search",
+		Description: "Shopify customer saved search is a search query that represents a group of customers, as defined by the shop owner.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getCustomerSavedSearch,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCustomerSavedSearches,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The customer saved search ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name given by the shop owner to the customer saved search.",
+			},
+			{
+				Name:        "query",
+				Type:        proto.ColumnType_STRING,
+				Description: "The set of conditions that determines which customers are in the customer saved search.",
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the customer saved search was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the customer saved search was last updated.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listCustomerSavedSearches(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_saved_search.listCustomerSavedSearches", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := goshopify.ListOptions{
+		Limit: 250,
+	}
+
+	for {
+		result := new(CustomerSavedSearchesResource)
+		paginator, err := conn.ListWithPagination("customer_saved_searches.json", result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_customer_saved_search.listCustomerSavedSearches", "api_error", err)
+			return nil, err
+		}
+
+		for _, savedSearch := range result.CustomerSavedSearches {
+			d.StreamListItem(ctx, savedSearch)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		options.PageInfo = paginator.NextPageOptions.PageInfo
+	}
+}
+
+func getCustomerSavedSearch(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_saved_search.getCustomerSavedSearch", "connection_error", err)
+		return nil, err
+	}
+
+	result := new(CustomerSavedSearchResource)
+	err = conn.Get(fmt.Sprintf("customer_saved_searches/%d.json", id), result, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_saved_search.getCustomerSavedSearch", "api_error", err)
+		return nil, err
+	}
+
+	return result.CustomerSavedSearch, nil
+}
@@ -0,0 +1,183 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type CustomerSavedSearchMember struct {
+	CustomerSavedSearch CustomerSavedSearch
+	Customer            goshopify.Customer
+}
+
+func tableShopifyCustomerSavedSearchMember(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_customer_saved_search_member",
+		Description: "Shopify customer saved search member is a customer that matches the query of a customer saved search.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listCustomerSavedSearchMemberSavedSearches,
+			Hydrate:       listCustomerSavedSearchMembers,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "customer_saved_search_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "customer_saved_search_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the customer saved search.",
+				Transform:   transform.FromField("CustomerSavedSearch.ID"),
+			},
+			{
+				Name:        "customer_saved_search_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the customer saved search.",
+				Transform:   transform.FromField("CustomerSavedSearch.Name"),
+			},
+			{
+				Name:        "customer_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The customer ID.",
+				Transform:   transform.FromField("Customer.ID"),
+			},
+			{
+				Name:        "email",
+				Type:        proto.ColumnType_STRING,
+				Description: "The Email ID of the customer.",
+				Transform:   transform.FromField("Customer.Email"),
+			},
+			{
+				Name:        "first_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "First name of the customer.",
+				Transform:   transform.FromField("Customer.FirstName"),
+			},
+			{
+				Name:        "last_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "Last name of the customer.",
+				Transform:   transform.FromField("Customer.LastName"),
+			},
+			{
+				Name:        "state",
+				Type:        proto.ColumnType_STRING,
+				Description: "Customer state.",
+				Transform:   transform.FromField("Customer.State"),
+			},
+			{
+				Name:        "orders_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The customer order count.",
+				Transform:   transform.FromField("Customer.OrdersCount"),
+			},
+			{
+				Name:        "total_spent",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "Total amount spent by the customer.",
+				Transform:   transform.FromField("Customer.TotalSpent").Transform(transform.ToString).Transform(transform.ToDouble),
+			},
+			{
+				Name:        "accepts_marketing",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the customer subscribed to email marketing campaign.",
+				Transform:   transform.FromField("Customer.AcceptsMarketing"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Customer.Email"),
+			},
+		}),
+	}
+}
+
+func listCustomerSavedSearchMemberSavedSearches(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	savedSearchID := d.EqualsQuals["customer_saved_search_id"].GetInt64Value()
+
+	// List every saved search if the saved search isn't known
+	if savedSearchID == 0 {
+		return listCustomerSavedSearches(ctx, d, h)
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_saved_search_member.listCustomerSavedSearchMemberSavedSearches", "connection_error", err)
+		return nil, err
+	}
+
+	// The saved search is fetched rather than stubbed, for the customer_saved_search_name column
+	result := new(CustomerSavedSearchResource)
+	err = conn.Get(fmt.Sprintf("customer_saved_searches/%d.json", savedSearchID), result, nil)
+	if err != nil {
+		// The saved search doesn't exist, so it has no members
+		var responseError goshopify.ResponseError
+		if errors.As(err, &responseError) && responseError.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("shopify_customer_saved_search_member.listCustomerSavedSearchMemberSavedSearches", "api_error", err)
+		return nil, err
+	}
+	if result.CustomerSavedSearch != nil {
+		d.StreamListItem(ctx, *result.CustomerSavedSearch)
+	}
+
+	return nil, nil
+}
+
+func listCustomerSavedSearchMembers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	savedSearch := h.Item.(CustomerSavedSearch)
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_saved_search_member.listCustomerSavedSearchMembers", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := goshopify.ListOptions{
+		Limit: 250,
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < 250 {
+			options.Limit = int(*limit)
+		}
+	}
+
+	path := fmt.Sprintf("customer_saved_searches/%d/customers.json", savedSearch.ID)
+	for {
+		result := new(goshopify.CustomersResource)
+		paginator, err := conn.ListWithPagination(path, result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_customer_saved_search_member.listCustomerSavedSearchMembers", "api_error", err)
+			return nil, err
+		}
+
+		for _, customer := range result.Customers {
+			d.StreamListItem(ctx, CustomerSavedSearchMember{
+				CustomerSavedSearch: savedSearch,
+				Customer:            customer,
+			})
+
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		options.PageInfo = paginator.NextPageOptions.PageInfo
+	}
+}
@@ -0,0 +1,148 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Segment represents a group of customers that match a ShopifyQL query.
+type Segment struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Query        string     `json:"query"`
+	CreationDate *time.Time `json:"creationDate"`
+	LastEditDate *time.Time `json:"lastEditDate"`
+}
+
+const segmentFields = `id
+name
+query
+creationDate
+lastEditDate`
+
+const listSegmentsQuery = `query($first: Int!, $after: String) {
+  segments(first: $first, after: $after) {
+    nodes {
+      ` + segmentFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getSegmentQuery = `query($id: ID!) {
+  segment(id: $id) {
+    ` + segmentFields + `
+  }
+}`
+
+func tableShopifyCustomerSegment(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_customer_segment",
+		Description: "Shopify customer segment is a group of customers that match a ShopifyQL query, used for marketing and discounts.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getCustomerSegment,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCustomerSegments,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the segment.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the segment.",
+			},
+			{
+				Name:        "query",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ShopifyQL query that defines the customers of the segment, e.g. 'number_of_orders > 3'.",
+			},
+			{
+				Name:        "creation_date",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the segment was created.",
+			},
+			{
+				Name:        "last_edit_date",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the segment was last edited.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listCustomerSegments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_segment.listCustomerSegments", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"first": graphQLPageSize(d, graphQLMaxPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listSegmentsQuery, variables, []string{"segments"}, func(segment Segment) bool {
+		d.StreamListItem(ctx, segment)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_segment.listCustomerSegments", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getCustomerSegment(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_segment.getCustomerSegment", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		Segment *Segment `json:"segment"`
+	}
+	_, err = client.Query(ctx, getSegmentQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_segment.getCustomerSegment", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the segment doesn't exist
+	if result.Segment == nil {
+		return nil, nil
+	}
+
+	return *result.Segment, nil
+}
@@ -0,0 +1,164 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// CustomerSegmentMember represents a customer that belongs to a segment.
+type CustomerSegmentMember struct {
+	ID                  string        `json:"id"`
+	DisplayName         string        `json:"displayName"`
+	FirstName           string        `json:"firstName"`
+	LastName            string        `json:"lastName"`
+	Locale              string        `json:"locale"`
+	NumberOfOrders      int64         `json:"numberOfOrders,string"`
+	LastOrderID         string        `json:"lastOrderId"`
+	AmountSpent         *graphQLMoney `json:"amountSpent"`
+	DefaultEmailAddress *struct {
+		EmailAddress string `json:"emailAddress"`
+	} `json:"defaultEmailAddress"`
+}
+
+const listCustomerSegmentMembersQuery = `query($segmentId: ID!, $first: Int!, $after: String) {
+  customerSegmentMembers(segmentId: $segmentId, first: $first, after: $after) {
+    edges {
+      node {
+        id
+        displayName
+        firstName
+        lastName
+        locale
+        numberOfOrders
+        lastOrderId
+        amountSpent {
+          amount
+          currencyCode
+        }
+        defaultEmailAddress {
+          emailAddress
+        }
+      }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+func tableShopifyCustomerSegmentMember(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_customer_segment_member",
+		Description: "Shopify customer segment member is a customer that belongs to a customer segment.",
+		List: &plugin.ListConfig{
+			Hydrate: listCustomerSegmentMembers,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "segment_id", Require: plugin.Required},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "segment_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the segment.",
+				Transform:   transform.FromQual("segment_id"),
+			},
+			{
+				Name:        "customer_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the customer.",
+				Transform:   transform.FromField("ID").Transform(gidToID),
+			},
+			{
+				Name:        "display_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The full name of the customer.",
+			},
+			{
+				Name:        "first_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The first name of the customer.",
+			},
+			{
+				Name:        "last_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The last name of the customer.",
+			},
+			{
+				Name:        "email",
+				Type:        proto.ColumnType_STRING,
+				Description: "The default email address of the customer.",
+				Transform:   transform.FromField("DefaultEmailAddress.EmailAddress"),
+			},
+			{
+				Name:        "locale",
+				Type:        proto.ColumnType_STRING,
+				Description: "The preferred locale of the customer.",
+			},
+			{
+				Name:        "amount_spent",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The total amount spent by the customer.",
+				Transform:   transform.FromField("AmountSpent.Amount").Transform(convertPrice),
+			},
+			{
+				Name:        "amount_spent_currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 code of the currency of the amount spent.",
+				Transform:   transform.FromField("AmountSpent.CurrencyCode"),
+			},
+			{
+				Name:        "number_of_orders",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of orders placed by the customer.",
+			},
+			{
+				Name:        "last_order_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the last order placed by the customer.",
+				Transform:   transform.FromField("LastOrderID").Transform(gidToID),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("DisplayName"),
+			},
+		}),
+	}
+}
+
+func listCustomerSegmentMembers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	segmentID := d.EqualsQualString("segment_id")
+	if segmentID == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_segment_member.listCustomerSegmentMembers", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"segmentId": segmentID,
+		"first":     graphQLPageSize(d, graphQLMaxPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listCustomerSegmentMembersQuery, variables, []string{"customerSegmentMembers"}, func(member CustomerSegmentMember) bool {
+		d.StreamListItem(ctx, member)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_customer_segment_member.listCustomerSegmentMembers", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,208 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Dispute represents a chargeback or inquiry raised against a Shopify Payments charge.
+type Dispute struct {
+	ID                int64      `json:"id,omitempty"`
+	OrderID           int64      `json:"order_id,omitempty"`
+	Type              string     `json:"type,omitempty"`
+	Amount            string     `json:"amount,omitempty"`
+	Currency          string     `json:"currency,omitempty"`
+	Reason            string     `json:"reason,omitempty"`
+	NetworkReasonCode string     `json:"network_reason_code,omitempty"`
+	Status            string     `json:"status,omitempty"`
+	EvidenceDueBy     *time.Time `json:"evidence_due_by,omitempty"`
+	EvidenceSentOn    *time.Time `json:"evidence_sent_on,omitempty"`
+	FinalizedOn       *time.Time `json:"finalized_on,omitempty"`
+	InitiatedAt       *time.Time `json:"initiated_at,omitempty"`
+}
+
+type DisputeResource struct {
+	Dispute *Dispute `json:"dispute"`
+}
+
+type DisputesResource struct {
+	Disputes []Dispute `json:"disputes"`
+}
+
+// DisputeListOptions are the options for the disputes endpoint
+type DisputeListOptions struct {
+	PageInfo string `url:"page_info,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	Status   string `url:"status,omitempty"`
+}
+
+func tableShopifyDispute(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_dispute",
+		Description: "Shopify dispute is a chargeback or inquiry raised by a customer's bank against a Shopify Payments charge.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getDispute,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listDisputes,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "status", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The dispute ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "order_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the order that the dispute belongs to.",
+				Transform:   transform.FromField("OrderID"),
+			},
+			{
+				Name:        "type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the dispute. Possible values are: chargeback and inquiry.",
+			},
+			{
+				Name:        "status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The current state of the dispute. Possible values are: needs_response, under_review, charge_refunded, accepted, won and lost.",
+			},
+			{
+				Name:        "reason",
+				Type:        proto.ColumnType_STRING,
+				Description: "The reason of the dispute provided by the cardholder's bank, e.g. fraudulent, product_not_received or duplicate.",
+			},
+			{
+				Name:        "network_reason_code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The reason code of the dispute provided by the card network.",
+			},
+			{
+				Name:        "amount",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The total amount disputed by the cardholder.",
+				Transform:   transform.FromField("Amount").Transform(transform.ToDouble),
+			},
+			{
+				Name:        "currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 currency code of the dispute amount.",
+			},
+			{
+				Name:        "evidence_due_by",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The deadline for the merchant to submit evidence.",
+			},
+			{
+				Name:        "evidence_sent_on",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the evidence was sent.",
+			},
+			{
+				Name:        "finalized_on",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the dispute was resolved.",
+			},
+			{
+				Name:        "initiated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the dispute was created.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("ID").Transform(transform.ToString),
+			},
+		}),
+	}
+}
+
+func listDisputes(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_dispute.listDisputes", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := DisputeListOptions{
+		Limit:  250,
+		Status: d.EqualsQualString("status"),
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < 250 {
+			options.Limit = int(*limit)
+		}
+	}
+
+	for {
+		result := new(DisputesResource)
+		paginator, err := conn.ListWithPagination("shopify_payments/disputes.json", result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_dispute.listDisputes", "api_error", err)
+			if isUnavailableError(err) {
+				return nil, fmt.Errorf("%s: %v", paymentsUnavailableMessage, err)
+			}
+			return nil, err
+		}
+
+		for _, dispute := range result.Disputes {
+			d.StreamListItem(ctx, dispute)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		// The filters are encoded in the page_info cursor and can't be passed again
+		options = DisputeListOptions{
+			PageInfo: paginator.NextPageOptions.PageInfo,
+			Limit:    options.Limit,
+		}
+	}
+}
+
+func getDispute(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_dispute.getDispute", "connection_error", err)
+		return nil, err
+	}
+
+	result := new(DisputeResource)
+	err = conn.Get(fmt.Sprintf("shopify_payments/disputes/%d.json", id), result, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_dispute.getDispute", "api_error", err)
+		return nil, err
+	}
+
+	return result.Dispute, nil
+}
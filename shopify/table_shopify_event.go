@@ -0,0 +1,184 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Event represents an action that happened in a Shopify store.
+type Event struct {
+	ID          int64       `json:"id,omitempty"`
+	SubjectID   int64       `json:"subject_id,omitempty"`
+	SubjectType string      `json:"subject_type,omitempty"`
+	Verb        string      `json:"verb,omitempty"`
+	Arguments   interface{} `json:"arguments,omitempty"`
+	Body        string      `json:"body,omitempty"`
+	Message     string      `json:"message,omitempty"`
+	Author      string      `json:"author,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Path        string      `json:"path,omitempty"`
+	CreatedAt   *time.Time  `json:"created_at,omitempty"`
+}
+
+type EventsResource struct {
+	Events []Event `json:"events"`
+}
+
+// EventListOptions are the options for the events endpoint
+type EventListOptions struct {
+	goshopify.ListOptions
+	Filter string `url:"filter,omitempty"`
+	Verb   string `url:"verb,omitempty"`
+}
+
+func tableShopifyEvent(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_event",
+		Description: "Shopify event is a record of an action that happened in the store, such as a product being created or an order being deleted.",
+		List: &plugin.ListConfig{
+			Hydrate: listEvents,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "subject_type", Require: plugin.Optional},
+				{Name: "verb", Require: plugin.Optional},
+				{Name: "created_at", Require: plugin.Optional, Operators: []string{">", ">=", "<", "<=", "="}},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The event ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "subject_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the resource that generated the event, e.g. Article, Blog, Collection, Comment, Order, Page, PriceRule or Product.",
+			},
+			{
+				Name:        "subject_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the resource that generated the event.",
+				Transform:   transform.FromField("SubjectID"),
+			},
+			{
+				Name:        "verb",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of event that occurred, e.g. create, destroy, published or unpublished.",
+			},
+			{
+				Name:        "author",
+				Type:        proto.ColumnType_STRING,
+				Description: "The author of the event.",
+			},
+			{
+				Name:        "message",
+				Type:        proto.ColumnType_STRING,
+				Description: "A human readable description of the event.",
+			},
+			{
+				Name:        "description",
+				Type:        proto.ColumnType_STRING,
+				Description: "A human readable description of the event, without markup.",
+			},
+			{
+				Name:        "body",
+				Type:        proto.ColumnType_STRING,
+				Description: "A text field containing information about the event.",
+			},
+			{
+				Name:        "path",
+				Type:        proto.ColumnType_STRING,
+				Description: "A relative URL to the resource the event is for, if applicable.",
+			},
+			{
+				Name:        "arguments",
+				Type:        proto.ColumnType_JSON,
+				Description: "Additional information about the event, such as the name of the resource that was changed.",
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the event was created.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Description"),
+			},
+		}),
+	}
+}
+
+func listEvents(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_event.listEvents", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := EventListOptions{
+		ListOptions: goshopify.ListOptions{
+			Limit: 250,
+		},
+		Filter: d.EqualsQualString("subject_type"),
+		Verb:   d.EqualsQualString("verb"),
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < 250 {
+			options.ListOptions.Limit = int(*limit)
+		}
+	}
+
+	if d.Quals["created_at"] != nil {
+		for _, q := range d.Quals["created_at"].Quals {
+			createdAt := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case ">", ">=":
+				options.ListOptions.CreatedAtMin = createdAt
+			case "<", "<=":
+				options.ListOptions.CreatedAtMax = createdAt
+			case "=":
+				options.ListOptions.CreatedAtMin = createdAt
+				options.ListOptions.CreatedAtMax = createdAt
+			}
+		}
+	}
+
+	for {
+		result := new(EventsResource)
+		paginator, err := conn.ListWithPagination("events.json", result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_event.listEvents", "api_error", err)
+			return nil, err
+		}
+
+		for _, event := range result.Events {
+			d.StreamListItem(ctx, event)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		// The filters are encoded in the page_info cursor and can't be passed again
+		options = EventListOptions{
+			ListOptions: *paginator.NextPageOptions,
+		}
+	}
+}
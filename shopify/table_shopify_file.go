@@ -0,0 +1,373 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// File represents a file uploaded to the Files section of the admin, i.e. a
+// generic file, an image or a video.
+type File struct {
+	ID               string      `json:"id"`
+	FileType         string      `json:"file_type"`
+	Alt              string      `json:"alt"`
+	URL              string      `json:"url"`
+	MimeType         string      `json:"mime_type"`
+	FileStatus       string      `json:"file_status"`
+	OriginalFileSize *int64      `json:"original_file_size"`
+	Width            *int        `json:"width"`
+	Height           *int        `json:"height"`
+	PreviewURL       string      `json:"preview_url"`
+	FileErrors       interface{} `json:"file_errors"`
+	CreatedAt        *time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time  `json:"updated_at"`
+}
+
+// fileNode is a node of the files connection, which has different fields for each type of file
+type fileNode struct {
+	Typename         string      `json:"__typename"`
+	ID               string      `json:"id"`
+	Alt              string      `json:"alt"`
+	FileStatus       string      `json:"fileStatus"`
+	FileErrors       interface{} `json:"fileErrors"`
+	CreatedAt        *time.Time  `json:"createdAt"`
+	UpdatedAt        *time.Time  `json:"updatedAt"`
+	URL              string      `json:"url"`
+	MimeType         string      `json:"mimeType"`
+	OriginalFileSize *int64      `json:"originalFileSize"`
+	Preview          *struct {
+		Image *struct {
+			URL string `json:"url"`
+		} `json:"image"`
+	} `json:"preview"`
+	Image *struct {
+		URL    string `json:"url"`
+		Width  *int   `json:"width"`
+		Height *int   `json:"height"`
+	} `json:"image"`
+	ImageSource *struct {
+		URL      string `json:"url"`
+		FileSize *int64 `json:"fileSize"`
+	} `json:"imageSource"`
+	VideoSource *struct {
+		URL      string `json:"url"`
+		MimeType string `json:"mimeType"`
+		FileSize *int64 `json:"fileSize"`
+		Width    *int   `json:"width"`
+		Height   *int   `json:"height"`
+	} `json:"videoSource"`
+}
+
+// toFile maps the fields of each type of file to the columns of the table
+func (n fileNode) toFile() File {
+	file := File{
+		ID:               n.ID,
+		FileType:         n.Typename,
+		Alt:              n.Alt,
+		URL:              n.URL,
+		MimeType:         n.MimeType,
+		FileStatus:       n.FileStatus,
+		OriginalFileSize: n.OriginalFileSize,
+		FileErrors:       n.FileErrors,
+		CreatedAt:        n.CreatedAt,
+		UpdatedAt:        n.UpdatedAt,
+	}
+	if n.Preview != nil && n.Preview.Image != nil {
+		file.PreviewURL = n.Preview.Image.URL
+	}
+	if n.Image != nil {
+		file.URL = n.Image.URL
+		file.Width = n.Image.Width
+		file.Height = n.Image.Height
+	}
+	if n.ImageSource != nil {
+		if file.URL == "" {
+			file.URL = n.ImageSource.URL
+		}
+		file.OriginalFileSize = n.ImageSource.FileSize
+	}
+	if n.VideoSource != nil {
+		file.URL = n.VideoSource.URL
+		file.MimeType = n.VideoSource.MimeType
+		file.OriginalFileSize = n.VideoSource.FileSize
+		file.Width = n.VideoSource.Width
+		file.Height = n.VideoSource.Height
+	}
+	return file
+}
+
+const fileFields = `__typename
+id
+alt
+fileStatus
+fileErrors {
+  code
+  details
+  message
+}
+createdAt
+updatedAt
+preview {
+  image {
+    url
+  }
+}
+... on GenericFile {
+  url
+  mimeType
+  originalFileSize
+}
+... on MediaImage {
+  mimeType
+  image {
+    url
+    width
+    height
+  }
+  imageSource: originalSource {
+    url
+    fileSize
+  }
+}
+... on Video {
+  videoSource: originalSource {
+    url
+    mimeType
+    fileSize
+    width
+    height
+  }
+}`
+
+const listFilesQuery = `query($first: Int!, $after: String, $query: String) {
+  files(first: $first, after: $after, query: $query) {
+    nodes {
+      ` + fileFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getFileQuery = `query($id: ID!) {
+  node(id: $id) {
+    ... on File {
+      ` + fileFields + `
+    }
+  }
+}`
+
+// the media type to search for each type of file
+var fileMediaTypes = map[string]string{
+	"GenericFile": "GENERIC_FILE",
+	"MediaImage":  "IMAGE",
+	"Video":       "VIDEO",
+}
+
+// Each file costs 6 points: the file, its errors, its preview with the image, and the
+// image and original source of a media image, the most expensive type of file. 100 of
+// them cost 2+100*6 = 602 points, below the max of 1000 points of a query.
+const filePageSize = 100
+
+func tableShopifyFile(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_file",
+		Description: "Shopify file is a file uploaded to the Files section of the admin, i.e. a generic file, an image or a video.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getFile,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listFiles,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "file_type", Require: plugin.Optional},
+				{Name: "file_status", Require: plugin.Optional},
+				{Name: "query", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the file.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "file_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the file. Possible values are: GenericFile, MediaImage and Video.",
+			},
+			{
+				Name:        "alt",
+				Type:        proto.ColumnType_STRING,
+				Description: "The alternative text of the file.",
+			},
+			{
+				Name:        "url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The URL of the file.",
+				Transform:   transform.FromField("URL"),
+			},
+			{
+				Name:        "mime_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The MIME type of the file, e.g. image/png.",
+			},
+			{
+				Name:        "file_status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The status of the file. Possible values are: uploaded, processing, ready and failed.",
+				Transform:   transform.FromField("FileStatus").Transform(transform.ToLower),
+			},
+			{
+				Name:        "original_file_size",
+				Type:        proto.ColumnType_INT,
+				Description: "The size of the file as uploaded, in bytes.",
+			},
+			{
+				Name:        "width",
+				Type:        proto.ColumnType_INT,
+				Description: "The width of the image or video, in pixels.",
+			},
+			{
+				Name:        "height",
+				Type:        proto.ColumnType_INT,
+				Description: "The height of the image or video, in pixels.",
+			},
+			{
+				Name:        "preview_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The URL of the preview image of the file.",
+				Transform:   transform.FromField("PreviewURL"),
+			},
+			{
+				Name:        "file_errors",
+				Type:        proto.ColumnType_JSON,
+				Description: "The errors that occurred while processing the file.",
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the file was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the file was last updated.",
+			},
+			{
+				Name:        "query",
+				Type:        proto.ColumnType_STRING,
+				Description: "A Shopify file search query, e.g. 'filename:logo created_at:>2024-01-01'. If set, only the files that match the query are listed.",
+				Transform:   transform.FromQual("query"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("URL").Transform(fileName),
+			},
+		}),
+	}
+}
+
+func listFiles(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_file.listFiles", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"first": graphQLPageSize(d, filePageSize),
+	}
+	if query := buildFileSearchQuery(d); query != "" {
+		variables["query"] = query
+	}
+	err = listGraphQLNodes(ctx, client, listFilesQuery, variables, []string{"files"}, func(node fileNode) bool {
+		d.StreamListItem(ctx, node.toFile())
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_file.listFiles", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getFile(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_file.getFile", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		Node *fileNode `json:"node"`
+	}
+	_, err = client.Query(ctx, getFileQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_file.getFile", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the file doesn't exist, and an empty object if the ID is not a file
+	if result.Node == nil || result.Node.ID == "" {
+		return nil, nil
+	}
+
+	return result.Node.toFile(), nil
+}
+
+// buildFileSearchQuery builds a Shopify file search query from the quals.
+// See https://shopify.dev/docs/api/admin-graphql/latest/queries/files
+func buildFileSearchQuery(d *plugin.QueryData) string {
+	var terms []string
+
+	if d.EqualsQualString("query") != "" {
+		terms = append(terms, d.EqualsQualString("query"))
+	}
+
+	if value := d.EqualsQualString("file_type"); value != "" {
+		// An unknown type can't be searched for, so it is left to be filtered by Steampipe
+		if mediaType, ok := fileMediaTypes[value]; ok {
+			terms = append(terms, fmt.Sprintf("media_type:%s", mediaType))
+		}
+	}
+
+	if value := d.EqualsQualString("file_status"); value != "" {
+		terms = append(terms, fmt.Sprintf("status:%s", quoteSearchValue(strings.ToUpper(value))))
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// fileName returns the name of a file from its URL
+func fileName(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	url, ok := d.Value.(string)
+	if !ok || url == "" {
+		return nil, nil
+	}
+	url, _, _ = strings.Cut(url, "?")
+	return url[strings.LastIndex(url, "/")+1:], nil
+}
@@ -0,0 +1,166 @@
+package shopify
+
+import (
+	"context"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableShopifyFulfillmentService(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_fulfillment_service",
+		Description: "Shopify fulfillment service is a third-party warehouse that prepares and ships orders on behalf of the store owner.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getFulfillmentService,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listFulfillmentServices,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The fulfillment service ID.",
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the fulfillment service as seen by merchants.",
+			},
+			{
+				Name:        "handle",
+				Type:        proto.ColumnType_STRING,
+				Description: "The human-readable unique identifier of the fulfillment service. This matches the fulfillment_service column of shopify_product_variant.",
+			},
+			{
+				Name:        "service_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the fulfillment service.",
+			},
+			{
+				Name:        "email",
+				Type:        proto.ColumnType_STRING,
+				Description: "The email address of the fulfillment service.",
+			},
+			{
+				Name:        "callback_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The callback URL that the fulfillment service has registered for requests.",
+				Transform:   transform.FromField("CallbackURL"),
+			},
+			{
+				Name:        "inventory_management",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the fulfillment service tracks product inventory and provides updates to Shopify.",
+			},
+			{
+				Name:        "tracking_support",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the fulfillment service provides tracking numbers for packages.",
+			},
+			{
+				Name:        "location_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the location that is associated with the fulfillment service.",
+				Transform:   transform.FromField("LocationId"),
+			},
+			{
+				Name:        "fulfillment_orders_opt_in",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the fulfillment service uses the fulfillment order based workflow for managing fulfillments.",
+			},
+			{
+				Name:        "include_pending_stock",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the fulfillment service includes pending stock in the inventory levels.",
+			},
+			{
+				Name:        "permits_sku_sharing",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the fulfillment service can stock inventory alongside other locations.",
+			},
+			{
+				Name:        "requires_shipping_method",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the fulfillment service requires products to be physically shipped.",
+			},
+			{
+				Name:        "provider_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the provider of the fulfillment service.",
+				Transform:   transform.FromField("ProviderId"),
+			},
+			{
+				Name:        "admin_graphql_api_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The admin graphql API ID of the fulfillment service.",
+				Transform:   transform.FromField("AdminGraphqlApiId"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listFulfillmentServices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_fulfillment_service.listFulfillmentServices", "connection_error", err)
+		return nil, err
+	}
+
+	// List all the fulfillment services of the store, not only the ones created by the app
+	options := goshopify.FulfillmentServiceOptions{
+		Scope: "all",
+	}
+
+	services, err := conn.FulfillmentService.List(options)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_fulfillment_service.listFulfillmentServices", "api_error", err)
+		return nil, err
+	}
+
+	for _, service := range services {
+		d.StreamListItem(ctx, service)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+func getFulfillmentService(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_fulfillment_service.getFulfillmentService", "connection_error", err)
+		return nil, err
+	}
+
+	result, err := conn.FulfillmentService.Get(id, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_fulfillment_service.getFulfillmentService", "api_error", err)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,279 @@
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// GraphQLQueryRow is a node of the first connection in the response of a GraphQL query
+type GraphQLQueryRow struct {
+	Query              string                 `json:"query"`
+	Variables          map[string]interface{} `json:"variables"`
+	Path               string                 `json:"path"`
+	Page               int                    `json:"page"`
+	Node               json.RawMessage        `json:"node"`
+	RequestedQueryCost int                    `json:"requested_query_cost"`
+	ActualQueryCost    int                    `json:"actual_query_cost"`
+	ThrottleStatus     *GraphQLThrottleStatus `json:"throttle_status"`
+}
+
+func tableShopifyGraphQLQuery(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_graphql_query",
+		Description: "Run a query against the Shopify GraphQL Admin API and return each node of the first connection in the response.",
+		List: &plugin.ListConfig{
+			Hydrate: listGraphQLQuery,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "query", Require: plugin.Required},
+				{Name: "variables", Require: plugin.Required},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "query",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL query to run. Documents that define a mutation or a subscription are rejected.",
+			},
+			{
+				Name:        "variables",
+				Type:        proto.ColumnType_JSON,
+				Description: "The variables of the query, e.g. {\"query\": \"status:active\"}.",
+			},
+			{
+				Name:        "path",
+				Type:        proto.ColumnType_STRING,
+				Description: "The path of the first connection in the response, e.g. products. This is null if the response doesn't have a connection, in which case the row contains the whole data of the response.",
+				Transform:   transform.FromField("Path").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "page",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of the page the node was returned in, starting at 1.",
+			},
+			{
+				Name:        "node",
+				Type:        proto.ColumnType_JSON,
+				Description: "The node, or the whole data of the response if it doesn't have a connection.",
+			},
+			{
+				Name:        "requested_query_cost",
+				Type:        proto.ColumnType_INT,
+				Description: "The cost of the page the node was returned in, as estimated before the query was run.",
+			},
+			{
+				Name:        "actual_query_cost",
+				Type:        proto.ColumnType_INT,
+				Description: "The cost of the page the node was returned in, as measured after the query was run.",
+			},
+			{
+				Name:        "throttle_status",
+				Type:        proto.ColumnType_JSON,
+				Description: "The query cost budget of the app after the page the node was returned in.",
+			},
+		}),
+	}
+}
+
+func listGraphQLQuery(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	query := d.EqualsQualString("query")
+	if query == "" {
+		return nil, nil
+	}
+
+	// The table is read-only, so the query can't change the store with the token of the connection
+	if err := checkGraphQLQueryOperations(query); err != nil {
+		plugin.Logger(ctx).Error("shopify_graphql_query.listGraphQLQuery", "invalid_query", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{}
+	if value := d.EqualsQuals["variables"].GetJsonbValue(); value != "" {
+		if err := json.Unmarshal([]byte(value), &variables); err != nil {
+			plugin.Logger(ctx).Error("shopify_graphql_query.listGraphQLQuery", "invalid_variables", err)
+			return nil, err
+		}
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_graphql_query.listGraphQLQuery", "connection_error", err)
+		return nil, err
+	}
+
+	// The connection can only be paged through if the query takes an $after variable
+	paginated := strings.Contains(query, "$after")
+
+	// The rows report the variables passed in the qual, not the cursor of the page
+	vars := map[string]interface{}{}
+	for k, v := range variables {
+		vars[k] = v
+	}
+
+	var path []string
+	for page := 1; ; page++ {
+		var data json.RawMessage
+		cost, err := client.Query(ctx, query, vars, &data)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_graphql_query.listGraphQLQuery", "api_error", err)
+			return nil, err
+		}
+
+		row := GraphQLQueryRow{
+			Query:     query,
+			Variables: variables,
+			Page:      page,
+		}
+		if cost != nil {
+			row.RequestedQueryCost = cost.RequestedQueryCost
+			row.ActualQueryCost = cost.ActualQueryCost
+			row.ThrottleStatus = cost.ThrottleStatus
+		}
+
+		// The connection is looked up on the first page, later pages have the same shape
+		if page == 1 {
+			path = findGraphQLConnection(data)
+		}
+		if path == nil {
+			row.Node = data
+			d.StreamListItem(ctx, row)
+			return nil, nil
+		}
+		row.Path = strings.Join(path, ".")
+
+		var connection struct {
+			Nodes []json.RawMessage `json:"nodes"`
+			Edges []struct {
+				Node json.RawMessage `json:"node"`
+			} `json:"edges"`
+			PageInfo GraphQLPageInfo `json:"pageInfo"`
+		}
+		found, err := decodeGraphQLPath(data, path, &connection)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_graphql_query.listGraphQLQuery", "decode_error", err)
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+
+		nodes := connection.Nodes
+		for _, edge := range connection.Edges {
+			nodes = append(nodes, edge.Node)
+		}
+		for _, node := range nodes {
+			row.Node = node
+			d.StreamListItem(ctx, row)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if !paginated || !connection.PageInfo.HasNextPage {
+			return nil, nil
+		}
+		vars["after"] = connection.PageInfo.EndCursor
+	}
+}
+
+// findGraphQLConnection returns the path of the first object in the data that
+// selects `nodes` or `edges`, in the order of the response. Lists aren't searched.
+func findGraphQLConnection(data json.RawMessage) []string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+		field, _ := token.(string)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil
+		}
+
+		var connection map[string]json.RawMessage
+		if err := json.Unmarshal(value, &connection); err != nil || connection == nil {
+			continue
+		}
+		if _, ok := connection["nodes"]; ok {
+			return []string{field}
+		}
+		if _, ok := connection["edges"]; ok {
+			return []string{field}
+		}
+		if path := findGraphQLConnection(value); path != nil {
+			return append([]string{field}, path...)
+		}
+	}
+	return nil
+}
+
+// checkGraphQLQueryOperations returns an error if the document defines a mutation or a
+// subscription. The keyword of each top-level definition is read, skipping the strings,
+// the comments and anything nested in braces or parentheses.
+func checkGraphQLQueryOperations(query string) error {
+	depth := 0
+	definition := true
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case strings.HasPrefix(query[i:], `"""`):
+			end := strings.Index(query[i+3:], `"""`)
+			if end < 0 {
+				return nil
+			}
+			i += end + 5
+		case c == '"':
+			for i++; i < len(query) && query[i] != '"' && query[i] != '\n'; i++ {
+				if query[i] == '\\' {
+					i++
+				}
+			}
+		case c == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '{' || c == '(' || c == '[':
+			if depth == 0 {
+				definition = false
+			}
+			depth++
+		case c == '}' || c == ')' || c == ']':
+			depth--
+			// The selection set of a definition is the last part of it
+			if depth == 0 && c == '}' {
+				definition = true
+			}
+		case c == '_' || c == '$' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+			start := i
+			for i+1 < len(query) && isGraphQLNameChar(query[i+1]) {
+				i++
+			}
+			if depth == 0 && definition {
+				switch name := query[start : i+1]; name {
+				case "mutation", "subscription":
+					return fmt.Errorf("the query can't define a %s, only queries can be run", name)
+				}
+				definition = false
+			}
+		}
+	}
+	return nil
+}
+
+func isGraphQLNameChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,262 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Market represents a group of regions that share the same pricing, languages and domains.
+type Market struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Handle           string                 `json:"handle"`
+	Enabled          bool                   `json:"enabled"`
+	Primary          bool                   `json:"primary"`
+	CurrencySettings MarketCurrencySettings `json:"currencySettings"`
+	WebPresence      interface{}            `json:"webPresence"`
+}
+
+// MarketCurrencySettings are the currency settings of a market
+type MarketCurrencySettings struct {
+	BaseCurrency struct {
+		CurrencyCode string `json:"currencyCode"`
+		CurrencyName string `json:"currencyName"`
+		Enabled      bool   `json:"enabled"`
+	} `json:"baseCurrency"`
+	LocalCurrencies bool `json:"localCurrencies"`
+}
+
+// MarketRegion is a country or region that belongs to a market
+type MarketRegion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code,omitempty"`
+}
+
+const marketFields = `id
+name
+handle
+enabled
+primary
+currencySettings {
+  baseCurrency {
+    currencyCode
+    currencyName
+    enabled
+  }
+  localCurrencies
+}
+webPresence {
+  id
+  subfolderSuffix
+  domain {
+    host
+    url
+  }
+  defaultLocale {
+    locale
+    name
+  }
+  alternateLocales {
+    locale
+    name
+  }
+  rootUrls {
+    locale
+    url
+  }
+}`
+
+const listMarketsQuery = `query($first: Int!, $after: String) {
+  markets(first: $first, after: $after) {
+    nodes {
+      ` + marketFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getMarketQuery = `query($id: ID!) {
+  market(id: $id) {
+    ` + marketFields + `
+  }
+}`
+
+const listMarketRegionsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  market(id: $id) {
+    regions(first: $first, after: $after) {
+      nodes {
+        id
+        name
+        ... on MarketRegionCountry {
+          code
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// Each market costs 8 points: the market, its currency settings and base currency, and
+// its web presence with its domain, default locale, alternate locales and root URLs. 100
+// of them cost 2+100*8 = 802 points, below the max of 1000 points of a query.
+const marketPageSize = 100
+
+func tableShopifyMarket(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_market",
+		Description: "Shopify market is a group of countries or regions that share the same currency, pricing, languages and domain.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getMarket,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMarkets,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the market.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the market.",
+			},
+			{
+				Name:        "handle",
+				Type:        proto.ColumnType_STRING,
+				Description: "The unique handle of the market.",
+			},
+			{
+				Name:        "enabled",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the market is enabled to receive visitors and sales.",
+			},
+			{
+				Name:        "primary",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the market is the primary market of the store.",
+			},
+			{
+				Name:        "base_currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 code of the currency the prices of the market are converted to.",
+				Transform:   transform.FromField("CurrencySettings.BaseCurrency.CurrencyCode"),
+			},
+			{
+				Name:        "currency_settings",
+				Type:        proto.ColumnType_JSON,
+				Description: "The currency settings of the market, i.e. the base currency and whether local currencies are used.",
+			},
+			{
+				Name:        "web_presence",
+				Type:        proto.ColumnType_JSON,
+				Description: "The domain or subfolder and the locales the market is served with.",
+			},
+			{
+				Name:        "regions",
+				Type:        proto.ColumnType_JSON,
+				Description: "The countries and regions that belong to the market.",
+				Hydrate:     listMarketRegions,
+				Transform:   transform.FromValue(),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listMarkets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_market.listMarkets", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"first": graphQLPageSize(d, marketPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listMarketsQuery, variables, []string{"markets"}, func(market Market) bool {
+		d.StreamListItem(ctx, market)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_market.listMarkets", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getMarket(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_market.getMarket", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		Market *Market `json:"market"`
+	}
+	_, err = client.Query(ctx, getMarketQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_market.getMarket", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the market doesn't exist
+	if result.Market == nil {
+		return nil, nil
+	}
+
+	return *result.Market, nil
+}
+
+func listMarketRegions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(Market).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_market.listMarketRegions", "connection_error", err)
+		return nil, err
+	}
+
+	var regions []MarketRegion
+	err = listGraphQLNodes(ctx, client, listMarketRegionsQuery, map[string]interface{}{"id": id}, []string{"market", "regions"}, func(region MarketRegion) bool {
+		regions = append(regions, region)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_market.listMarketRegions", "api_error", err)
+		return nil, err
+	}
+
+	return regions, nil
+}
@@ -0,0 +1,247 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/shopspring/decimal"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// MarketingEvent represents a marketing campaign or activity that drives traffic to the store.
+type MarketingEvent struct {
+	ID                int64            `json:"id,omitempty"`
+	EventType         string           `json:"event_type,omitempty"`
+	MarketingChannel  string           `json:"marketing_channel,omitempty"`
+	Paid              bool             `json:"paid,omitempty"`
+	Budget            *decimal.Decimal `json:"budget,omitempty"`
+	BudgetType        string           `json:"budget_type,omitempty"`
+	Currency          string           `json:"currency,omitempty"`
+	Description       string           `json:"description,omitempty"`
+	RemoteID          string           `json:"remote_id,omitempty"`
+	ReferringDomain   string           `json:"referring_domain,omitempty"`
+	UTMCampaign       string           `json:"utm_campaign,omitempty"`
+	UTMSource         string           `json:"utm_source,omitempty"`
+	UTMMedium         string           `json:"utm_medium,omitempty"`
+	ManageURL         string           `json:"manage_url,omitempty"`
+	PreviewURL        string           `json:"preview_url,omitempty"`
+	BreadcrumbID      string           `json:"breadcrumb_id,omitempty"`
+	MarketedResources interface{}      `json:"marketed_resources,omitempty"`
+	StartedAt         *time.Time       `json:"started_at,omitempty"`
+	EndedAt           *time.Time       `json:"ended_at,omitempty"`
+	ScheduledToEndAt  *time.Time       `json:"scheduled_to_end_at,omitempty"`
+	AdminGraphqlAPIID string           `json:"admin_graphql_api_id,omitempty"`
+}
+
+type MarketingEventResource struct {
+	MarketingEvent *MarketingEvent `json:"marketing_event"`
+}
+
+type MarketingEventsResource struct {
+	MarketingEvents []MarketingEvent `json:"marketing_events"`
+}
+
+func tableShopifyMarketingEvent(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_marketing_event",
+		Description: "Shopify marketing event is a marketing campaign or activity, such as an ad or an email, that drives traffic to the store.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getMarketingEvent,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMarketingEvents,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The marketing event ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "event_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the marketing event, e.g. ad, post, message, newsletter, affiliate or abandoned_cart.",
+			},
+			{
+				Name:        "marketing_channel",
+				Type:        proto.ColumnType_STRING,
+				Description: "The channel that the marketing event is running on. Possible values are: search, display, social, email and referral.",
+			},
+			{
+				Name:        "paid",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the marketing event is a paid marketing event.",
+			},
+			{
+				Name:        "budget",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The budget of the marketing event.",
+				Transform:   transform.FromField("Budget").Transform(convertPrice),
+			},
+			{
+				Name:        "budget_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the budget. Possible values are: daily and lifetime.",
+			},
+			{
+				Name:        "currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 currency code of the budget.",
+			},
+			{
+				Name:        "description",
+				Type:        proto.ColumnType_STRING,
+				Description: "A description of the marketing event.",
+			},
+			{
+				Name:        "utm_campaign",
+				Type:        proto.ColumnType_STRING,
+				Description: "The UTM campaign parameter of the marketing event.",
+				Transform:   transform.FromField("UTMCampaign"),
+			},
+			{
+				Name:        "utm_source",
+				Type:        proto.ColumnType_STRING,
+				Description: "The UTM source parameter of the marketing event.",
+				Transform:   transform.FromField("UTMSource"),
+			},
+			{
+				Name:        "utm_medium",
+				Type:        proto.ColumnType_STRING,
+				Description: "The UTM medium parameter of the marketing event.",
+				Transform:   transform.FromField("UTMMedium"),
+			},
+			{
+				Name:        "referring_domain",
+				Type:        proto.ColumnType_STRING,
+				Description: "The domain where the marketing event originated.",
+			},
+			{
+				Name:        "remote_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the marketing event in the platform that runs it.",
+				Transform:   transform.FromField("RemoteID"),
+			},
+			{
+				Name:        "started_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the marketing event started.",
+			},
+			{
+				Name:        "ended_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the marketing event ended.",
+			},
+			{
+				Name:        "scheduled_to_end_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the marketing event is scheduled to end.",
+			},
+			{
+				Name:        "manage_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "A link to manage the marketing event.",
+				Transform:   transform.FromField("ManageURL"),
+			},
+			{
+				Name:        "preview_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "A link to view the live version of the marketing event.",
+				Transform:   transform.FromField("PreviewURL"),
+			},
+			{
+				Name:        "marketed_resources",
+				Type:        proto.ColumnType_JSON,
+				Description: "The products, collections, pages or other resources that the marketing event promotes.",
+			},
+			{
+				Name:        "admin_graphql_api_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The admin graphql API ID of the marketing event.",
+				Transform:   transform.FromField("AdminGraphqlAPIID"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("UTMCampaign"),
+			},
+		}),
+	}
+}
+
+func listMarketingEvents(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_marketing_event.listMarketingEvents", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := goshopify.ListOptions{
+		Limit: 250,
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < 250 {
+			options.Limit = int(*limit)
+		}
+	}
+
+	for {
+		result := new(MarketingEventsResource)
+		paginator, err := conn.ListWithPagination("marketing_events.json", result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_marketing_event.listMarketingEvents", "api_error", err)
+			return nil, err
+		}
+
+		for _, event := range result.MarketingEvents {
+			d.StreamListItem(ctx, event)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		options.PageInfo = paginator.NextPageOptions.PageInfo
+	}
+}
+
+func getMarketingEvent(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_marketing_event.getMarketingEvent", "connection_error", err)
+		return nil, err
+	}
+
+	result := new(MarketingEventResource)
+	err = conn.Get(fmt.Sprintf("marketing_events/%d.json", id), result, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_marketing_event.getMarketingEvent", "api_error", err)
+		return nil, err
+	}
+
+	return result.MarketingEvent, nil
+}
@@ -0,0 +1,173 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// metafieldOwnerResources maps the owner_resource value returned by the API to
+// the path segment used to list the metafields of that owner.
+var metafieldOwnerResources = map[string]string{
+	"shop":       "",
+	"product":    "products",
+	"variant":    "variants",
+	"customer":   "customers",
+	"order":      "orders",
+	"collection": "collections",
+	"page":       "pages",
+	"article":    "articles",
+}
+
+func tableShopifyMetafield(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_metafield",
+		Description: "Shopify metafields store additional information about shops, products, variants, customers, orders, collections, pages and articles.",
+		List: &plugin.ListConfig{
+			Hydrate: listMetafields,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "owner_resource", Require: plugin.Optional},
+				{Name: "owner_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The metafield ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "namespace",
+				Type:        proto.ColumnType_STRING,
+				Description: "The container for a group of metafields.",
+			},
+			{
+				Name:        "key",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the metafield.",
+			},
+			{
+				Name:        "value",
+				Type:        proto.ColumnType_STRING,
+				Description: "The data stored in the metafield.",
+				Transform:   transform.FromField("Value").Transform(transform.ToString),
+			},
+			{
+				Name:        "type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of data that the metafield stores in the value field.",
+			},
+			{
+				Name:        "description",
+				Type:        proto.ColumnType_STRING,
+				Description: "A description of the information that the metafield contains.",
+			},
+			{
+				Name:        "owner_resource",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of resource that the metafield is attached to. Possible values are: shop, product, variant, customer, order, collection, page and article.",
+			},
+			{
+				Name:        "owner_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the resource that the metafield is attached to.",
+				Transform:   transform.FromField("OwnerId"),
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the metafield was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the metafield was last updated.",
+			},
+			{
+				Name:        "admin_graphql_api_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The admin graphql API ID of the metafield.",
+				Transform:   transform.FromField("AdminGraphqlAPIID"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Key"),
+			},
+		}),
+	}
+}
+
+func listMetafields(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	ownerResource := "shop"
+	if d.EqualsQualString("owner_resource") != "" {
+		ownerResource = d.EqualsQualString("owner_resource")
+	}
+	ownerID := d.EqualsQuals["owner_id"].GetInt64Value()
+
+	resource, ok := metafieldOwnerResources[ownerResource]
+	if !ok {
+		return nil, fmt.Errorf("unsupported owner_resource '%s'. Supported values are: shop, product, variant, customer, order, collection, page and article", ownerResource)
+	}
+
+	// An owner_id without an owner_resource would silently list the shop's metafields instead
+	if d.EqualsQualString("owner_resource") == "" && ownerID != 0 {
+		return nil, fmt.Errorf("'owner_resource' must be set in the where clause when owner_id is set")
+	}
+
+	// Only the shop's own metafields can be listed without an owner
+	if resource != "" && ownerID == 0 {
+		return nil, fmt.Errorf("'owner_id' must be set in the where clause when owner_resource is '%s'", ownerResource)
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metafield.listMetafields", "connection_error", err)
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s.json", goshopify.MetafieldPathPrefix(resource, ownerID))
+
+	// the max limit defined by the API is 250
+	options := goshopify.ListOptions{
+		Limit: 250,
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < 250 {
+			options.Limit = int(*limit)
+		}
+	}
+
+	for {
+		result := new(goshopify.MetafieldsResource)
+		paginator, err := conn.ListWithPagination(path, result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_metafield.listMetafields", "api_error", err)
+			return nil, err
+		}
+
+		for _, metafield := range result.Metafields {
+			d.StreamListItem(ctx, metafield)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		options.PageInfo = paginator.NextPageOptions.PageInfo
+	}
+}
@@ -0,0 +1,213 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Metaobject represents an entry of custom content, such as a size guide or a store location.
+type Metaobject struct {
+	ID           string            `json:"id"`
+	Handle       string            `json:"handle"`
+	Type         string            `json:"type"`
+	DisplayName  string            `json:"displayName"`
+	UpdatedAt    *time.Time        `json:"updatedAt"`
+	Capabilities interface{}       `json:"capabilities"`
+	Fields       []MetaobjectField `json:"fields"`
+}
+
+// MetaobjectField is a field of a metaobject
+type MetaobjectField struct {
+	Key       string      `json:"key"`
+	Type      string      `json:"type"`
+	Value     *string     `json:"value"`
+	JSONValue interface{} `json:"jsonValue"`
+}
+
+const metaobjectFields = `id
+handle
+type
+displayName
+updatedAt
+capabilities {
+  publishable {
+    status
+  }
+}
+fields {
+  key
+  type
+  value
+  jsonValue
+}`
+
+const listMetaobjectsQuery = `query($type: String!, $first: Int!, $after: String) {
+  metaobjects(type: $type, first: $first, after: $after) {
+    nodes {
+      ` + metaobjectFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getMetaobjectQuery = `query($id: ID!) {
+  metaobject(id: $id) {
+    ` + metaobjectFields + `
+  }
+}`
+
+// Each metaobject costs 4 points: the metaobject, its capabilities, the publishable
+// capability and its fields. 200 of them cost 2+200*4 = 802 points, below the max of
+// 1000 points of a query.
+const metaobjectPageSize = 200
+
+func tableShopifyMetaobject(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_metaobject",
+		Description: "Shopify metaobject is an entry of custom content, such as a size guide or a store location, structured by a metaobject definition.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getMetaobject,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMetaobjects,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "type", Require: plugin.Required},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the metaobject.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the metaobject, as defined by its metaobject definition.",
+			},
+			{
+				Name:        "handle",
+				Type:        proto.ColumnType_STRING,
+				Description: "The unique handle of the metaobject within its type.",
+			},
+			{
+				Name:        "display_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The display name of the metaobject, i.e. the value of the field set as the display name of the definition.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the metaobject was last updated.",
+			},
+			{
+				Name:        "fields",
+				Type:        proto.ColumnType_JSON,
+				Description: "The values of the fields of the metaobject by key, typed according to the field definitions, e.g. numbers, booleans or lists.",
+				Transform:   transform.FromField("Fields").Transform(metaobjectFieldValues),
+			},
+			{
+				Name:        "field_list",
+				Type:        proto.ColumnType_JSON,
+				Description: "The fields of the metaobject, with their key, type and value as a string.",
+				Transform:   transform.FromField("Fields"),
+			},
+			{
+				Name:        "capabilities",
+				Type:        proto.ColumnType_JSON,
+				Description: "The state of the capabilities of the metaobject, e.g. its publishing status.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("DisplayName"),
+			},
+		}),
+	}
+}
+
+func listMetaobjects(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	metaobjectType := d.EqualsQualString("type")
+	if metaobjectType == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metaobject.listMetaobjects", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"type":  metaobjectType,
+		"first": graphQLPageSize(d, metaobjectPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listMetaobjectsQuery, variables, []string{"metaobjects"}, func(metaobject Metaobject) bool {
+		d.StreamListItem(ctx, metaobject)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metaobject.listMetaobjects", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getMetaobject(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metaobject.getMetaobject", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		Metaobject *Metaobject `json:"metaobject"`
+	}
+	_, err = client.Query(ctx, getMetaobjectQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metaobject.getMetaobject", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the metaobject doesn't exist
+	if result.Metaobject == nil {
+		return nil, nil
+	}
+
+	return result.Metaobject, nil
+}
+
+// metaobjectFieldValues returns the typed values of the fields of a metaobject by key
+func metaobjectFieldValues(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	fields, ok := d.Value.([]MetaobjectField)
+	if !ok {
+		return nil, nil
+	}
+	values := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		values[field.Key] = field.JSONValue
+	}
+	return values, nil
+}
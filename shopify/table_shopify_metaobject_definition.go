@@ -0,0 +1,209 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// MetaobjectDefinition represents the structure of a type of metaobject.
+type MetaobjectDefinition struct {
+	ID               string      `json:"id"`
+	Type             string      `json:"type"`
+	Name             string      `json:"name"`
+	Description      string      `json:"description"`
+	DisplayNameKey   string      `json:"displayNameKey"`
+	MetaobjectsCount int         `json:"metaobjectsCount"`
+	FieldDefinitions interface{} `json:"fieldDefinitions"`
+	Access           interface{} `json:"access"`
+	Capabilities     interface{} `json:"capabilities"`
+}
+
+const metaobjectDefinitionFields = `id
+type
+name
+description
+displayNameKey
+metaobjectsCount
+fieldDefinitions {
+  key
+  name
+  description
+  required
+  type {
+    name
+    category
+  }
+  validations {
+    name
+    value
+  }
+}
+access {
+  admin
+  storefront
+}
+capabilities {
+  publishable {
+    enabled
+  }
+  translatable {
+    enabled
+  }
+  renderable {
+    enabled
+  }
+  onlineStore {
+    enabled
+  }
+}`
+
+const listMetaobjectDefinitionsQuery = `query($first: Int!, $after: String) {
+  metaobjectDefinitions(first: $first, after: $after) {
+    nodes {
+      ` + metaobjectDefinitionFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getMetaobjectDefinitionQuery = `query($id: ID!) {
+  metaobjectDefinition(id: $id) {
+    ` + metaobjectDefinitionFields + `
+  }
+}`
+
+// Each definition costs 10 points: the definition, its field definitions with their type
+// and validations, its access, and its capabilities with the 4 selected capabilities. 50
+// of them cost 2+50*10 = 502 points, below the max of 1000 points of a query.
+const metaobjectDefinitionPageSize = 50
+
+func tableShopifyMetaobjectDefinition(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_metaobject_definition",
+		Description: "Shopify metaobject definition is the structure of a type of metaobject, i.e. custom content such as size guides or store locations.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getMetaobjectDefinition,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMetaobjectDefinitions,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the metaobject definition.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the metaobjects of the definition, e.g. size_guide.",
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The human-readable name of the metaobject definition.",
+			},
+			{
+				Name:        "description",
+				Type:        proto.ColumnType_STRING,
+				Description: "The description of the metaobject definition.",
+			},
+			{
+				Name:        "display_name_key",
+				Type:        proto.ColumnType_STRING,
+				Description: "The key of the field used as the display name of the metaobjects.",
+			},
+			{
+				Name:        "metaobjects_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of metaobjects of the definition.",
+			},
+			{
+				Name:        "field_definitions",
+				Type:        proto.ColumnType_JSON,
+				Description: "The fields of the metaobjects, with their key, name, type, validations and whether they are required.",
+			},
+			{
+				Name:        "access",
+				Type:        proto.ColumnType_JSON,
+				Description: "The access granted to the metaobjects in the admin and on the storefront.",
+			},
+			{
+				Name:        "capabilities",
+				Type:        proto.ColumnType_JSON,
+				Description: "The capabilities enabled on the metaobject definition, e.g. publishable or translatable.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listMetaobjectDefinitions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metaobject_definition.listMetaobjectDefinitions", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"first": graphQLPageSize(d, metaobjectDefinitionPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listMetaobjectDefinitionsQuery, variables, []string{"metaobjectDefinitions"}, func(definition MetaobjectDefinition) bool {
+		d.StreamListItem(ctx, definition)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metaobject_definition.listMetaobjectDefinitions", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getMetaobjectDefinition(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metaobject_definition.getMetaobjectDefinition", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		MetaobjectDefinition *MetaobjectDefinition `json:"metaobjectDefinition"`
+	}
+	_, err = client.Query(ctx, getMetaobjectDefinitionQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_metaobject_definition.getMetaobjectDefinition", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the metaobject definition doesn't exist
+	if result.MetaobjectDefinition == nil {
+		return nil, nil
+	}
+
+	return result.MetaobjectDefinition, nil
+}
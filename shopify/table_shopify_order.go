@@ -2,8 +2,14 @@ package shopify
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/shopspring/decimal"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -19,6 +25,9 @@ func tableShopifyOrder(ctx context.Context) *plugin.Table {
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listOrders,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "customer_id", Require: plugin.Optional},
+			},
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{
@@ -62,6 +71,12 @@ func tableShopifyOrder(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_TIMESTAMP,
 				Description: "The time when the order was processed.",
 			},
+			{
+				Name:        "customer_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the customer who placed the order.",
+				Transform:   transform.FromField("Customer.ID"),
+			},
 			{
 				Name:        "customer",
 				Type:        proto.ColumnType_JSON,
@@ -391,8 +406,29 @@ func listOrders(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		options.ListOptions.Limit = int(maxLimit)
 	}
 
+	// List the orders of a single customer using the customer orders endpoint
+	customerID := d.EqualsQuals["customer_id"].GetInt64Value()
+
+	// Read the whole table with a bulk operation if they are enabled in the connection config
+	if customerID == 0 && useBulkOperations(d) {
+		return listOrdersBulk(ctx, d)
+	}
+
+	// The orders of a customer include the closed and cancelled ones, like the orders column of shopify_customer
+	if customerID != 0 {
+		options.Status = "any"
+	}
+
 	for {
-		orders, paginator, err := conn.Order.ListWithPagination(options)
+		var orders []goshopify.Order
+		var paginator *goshopify.Pagination
+		if customerID != 0 {
+			result := new(goshopify.OrdersResource)
+			paginator, err = conn.ListWithPagination(fmt.Sprintf("customers/%d/orders.json", customerID), result, options)
+			orders = result.Orders
+		} else {
+			orders, paginator, err = conn.Order.ListWithPagination(options)
+		}
 		if err != nil {
 			plugin.Logger(ctx).Error("shopify_order.listOrders", "api_error", err)
 			return nil, err
@@ -409,7 +445,13 @@ func listOrders(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		if paginator.NextPageOptions == nil {
 			return nil, nil
 		}
-		options.ListOptions.PageInfo = paginator.NextPageOptions.PageInfo
+		// Only the limit can be passed along with the page_info cursor, the status applies to every page
+		options = goshopify.OrderListOptions{
+			ListOptions: goshopify.ListOptions{
+				PageInfo: paginator.NextPageOptions.PageInfo,
+				Limit:    options.Limit,
+			},
+		}
 	}
 }
 
@@ -436,3 +478,476 @@ func getOrder(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (
 
 	return result, nil
 }
+
+// bulkOrdersQuery selects the orders, their line items and their shipping lines for
+// a bulk operation. Like the REST API, it only returns the open orders.
+const bulkOrdersQuery = `{
+  orders(query: "status:open") {
+    edges {
+      node {
+        id
+        name
+        number
+        email
+        phone
+        createdAt
+        updatedAt
+        cancelledAt
+        closedAt
+        processedAt
+        customer {
+          id
+        }
+        billingAddress {
+          ` + graphQLMailingAddressFields + `
+        }
+        shippingAddress {
+          ` + graphQLMailingAddressFields + `
+        }
+        currencyCode
+        totalPriceSet {
+          shopMoney {
+            amount
+          }
+        }
+        currentTotalPriceSet {
+          shopMoney {
+            amount
+          }
+        }
+        subtotalPriceSet {
+          shopMoney {
+            amount
+          }
+        }
+        totalDiscountsSet {
+          shopMoney {
+            amount
+          }
+        }
+        totalTaxSet {
+          shopMoney {
+            amount
+          }
+        }
+        taxesIncluded
+        totalWeight
+        displayFinancialStatus
+        displayFulfillmentStatus
+        note
+        test
+        clientIp
+        cancelReason
+        confirmed
+        customerLocale
+        sourceName
+        sourceIdentifier
+        paymentGatewayNames
+        tags
+        discountCodes
+        taxLines {
+          ` + bulkTaxLineFields + `
+        }
+        customAttributes {
+          key
+          value
+        }
+        customerJourneySummary {
+          firstVisit {
+            referrerUrl
+          }
+        }
+        lineItems {
+          edges {
+            node {
+              id
+              name
+              title
+              variantTitle
+              sku
+              vendor
+              quantity
+              isGiftCard
+              taxable
+              requiresShipping
+              originalUnitPriceSet {
+                shopMoney {
+                  amount
+                }
+              }
+              totalDiscountSet {
+                shopMoney {
+                  amount
+                }
+              }
+              product {
+                id
+              }
+              variant {
+                id
+              }
+            }
+          }
+        }
+        shippingLines {
+          edges {
+            node {
+              id
+              title
+              code
+              source
+              phone
+              carrierIdentifier
+              deliveryCategory
+              originalPriceSet {
+                shopMoney {
+                  amount
+                }
+              }
+              discountedPriceSet {
+                shopMoney {
+                  amount
+                }
+              }
+              taxLines {
+                ` + bulkTaxLineFields + `
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const bulkTaxLineFields = `title
+rate
+priceSet {
+  shopMoney {
+    amount
+  }
+}`
+
+// bulkOrderColumns are the columns of the orders listed with a bulk operation.
+// The other columns aren't available in the GraphQL Admin API.
+var bulkOrderColumns = []string{
+	"id",
+	"name",
+	"email",
+	"created_at",
+	"updated_at",
+	"cancelled_at",
+	"closed_at",
+	"processed_at",
+	"customer_id",
+	"billing_address",
+	"shipping_address",
+	"currency",
+	"total_price",
+	"current_total_price",
+	"subtotal_price",
+	"total_discounts",
+	"taxes_included",
+	"total_tax",
+	"tax_lines",
+	"total_weight",
+	"financial_status",
+	"fulfillment_status",
+	"number",
+	"order_number",
+	"note",
+	"test",
+	"browser_ip",
+	"cancel_reason",
+	"note_attributes",
+	"discount_codes",
+	"line_items",
+	"shipping_lines",
+	"customer_locale",
+	"referring_site",
+	"source_name",
+	"tags",
+	"payment_gateway_names",
+	"confirmed",
+	"source_identifier",
+	"phone",
+	"title",
+}
+
+// bulkOrder is an order in the result of a bulk operation
+type bulkOrder struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Number      int        `json:"number"`
+	Email       string     `json:"email"`
+	Phone       string     `json:"phone"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+	CancelledAt *time.Time `json:"cancelledAt"`
+	ClosedAt    *time.Time `json:"closedAt"`
+	ProcessedAt *time.Time `json:"processedAt"`
+	Customer    *struct {
+		ID string `json:"id"`
+	} `json:"customer"`
+	BillingAddress           *graphQLMailingAddress `json:"billingAddress"`
+	ShippingAddress          *graphQLMailingAddress `json:"shippingAddress"`
+	CurrencyCode             string                 `json:"currencyCode"`
+	TotalPriceSet            *graphQLMoneyBag       `json:"totalPriceSet"`
+	CurrentTotalPriceSet     *graphQLMoneyBag       `json:"currentTotalPriceSet"`
+	SubtotalPriceSet         *graphQLMoneyBag       `json:"subtotalPriceSet"`
+	TotalDiscountsSet        *graphQLMoneyBag       `json:"totalDiscountsSet"`
+	TotalTaxSet              *graphQLMoneyBag       `json:"totalTaxSet"`
+	TaxesIncluded            bool                   `json:"taxesIncluded"`
+	TotalWeight              string                 `json:"totalWeight"`
+	DisplayFinancialStatus   string                 `json:"displayFinancialStatus"`
+	DisplayFulfillmentStatus string                 `json:"displayFulfillmentStatus"`
+	Note                     string                 `json:"note"`
+	Test                     bool                   `json:"test"`
+	ClientIP                 string                 `json:"clientIp"`
+	CancelReason             string                 `json:"cancelReason"`
+	Confirmed                bool                   `json:"confirmed"`
+	CustomerLocale           string                 `json:"customerLocale"`
+	SourceName               string                 `json:"sourceName"`
+	SourceIdentifier         string                 `json:"sourceIdentifier"`
+	PaymentGatewayNames      []string               `json:"paymentGatewayNames"`
+	Tags                     []string               `json:"tags"`
+	DiscountCodes            []string               `json:"discountCodes"`
+	TaxLines                 []bulkTaxLine          `json:"taxLines"`
+	CustomAttributes         []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"customAttributes"`
+	CustomerJourneySummary *struct {
+		FirstVisit *struct {
+			ReferrerURL string `json:"referrerUrl"`
+		} `json:"firstVisit"`
+	} `json:"customerJourneySummary"`
+}
+
+// bulkTaxLine is a tax line in the result of a bulk operation
+type bulkTaxLine struct {
+	Title    string           `json:"title"`
+	Rate     *decimal.Decimal `json:"rate"`
+	PriceSet *graphQLMoneyBag `json:"priceSet"`
+}
+
+// bulkShippingLine is a shipping line in the result of a bulk operation
+type bulkShippingLine struct {
+	ID                 string           `json:"id"`
+	Title              string           `json:"title"`
+	Code               string           `json:"code"`
+	Source             string           `json:"source"`
+	Phone              string           `json:"phone"`
+	CarrierIdentifier  string           `json:"carrierIdentifier"`
+	DeliveryCategory   string           `json:"deliveryCategory"`
+	OriginalPriceSet   *graphQLMoneyBag `json:"originalPriceSet"`
+	DiscountedPriceSet *graphQLMoneyBag `json:"discountedPriceSet"`
+	TaxLines           []bulkTaxLine    `json:"taxLines"`
+}
+
+// bulkLineItem is a line item in the result of a bulk operation
+type bulkLineItem struct {
+	ID                   string           `json:"id"`
+	Name                 string           `json:"name"`
+	Title                string           `json:"title"`
+	VariantTitle         string           `json:"variantTitle"`
+	SKU                  string           `json:"sku"`
+	Vendor               string           `json:"vendor"`
+	Quantity             int              `json:"quantity"`
+	IsGiftCard           bool             `json:"isGiftCard"`
+	Taxable              bool             `json:"taxable"`
+	RequiresShipping     bool             `json:"requiresShipping"`
+	OriginalUnitPriceSet *graphQLMoneyBag `json:"originalUnitPriceSet"`
+	TotalDiscountSet     *graphQLMoneyBag `json:"totalDiscountSet"`
+	Product              *struct {
+		ID string `json:"id"`
+	} `json:"product"`
+	Variant *struct {
+		ID string `json:"id"`
+	} `json:"variant"`
+}
+
+// bulkFulfillmentStatuses maps the GraphQL fulfillment statuses to the REST ones.
+// The REST API doesn't set a fulfillment status on unfulfilled orders.
+var bulkFulfillmentStatuses = map[string]string{
+	"FULFILLED":           "fulfilled",
+	"PARTIALLY_FULFILLED": "partial",
+	"RESTOCKED":           "restocked",
+}
+
+func (o bulkOrder) toOrder() goshopify.Order {
+	order := goshopify.Order{
+		ID:                  parseGID(o.ID),
+		Name:                o.Name,
+		Email:               o.Email,
+		Phone:               o.Phone,
+		CreatedAt:           o.CreatedAt,
+		UpdatedAt:           o.UpdatedAt,
+		CancelledAt:         o.CancelledAt,
+		ClosedAt:            o.ClosedAt,
+		ProcessedAt:         o.ProcessedAt,
+		BillingAddress:      o.BillingAddress.toAddress(),
+		ShippingAddress:     o.ShippingAddress.toAddress(),
+		Currency:            o.CurrencyCode,
+		TotalPrice:          o.TotalPriceSet.amount(),
+		CurrentTotalPrice:   o.CurrentTotalPriceSet.amount(),
+		SubtotalPrice:       o.SubtotalPriceSet.amount(),
+		TotalDiscounts:      o.TotalDiscountsSet.amount(),
+		TotalTax:            o.TotalTaxSet.amount(),
+		TaxesIncluded:       o.TaxesIncluded,
+		FinancialStatus:     strings.ToLower(o.DisplayFinancialStatus),
+		FulfillmentStatus:   bulkFulfillmentStatuses[o.DisplayFulfillmentStatus],
+		Note:                o.Note,
+		Test:                o.Test,
+		BrowserIp:           o.ClientIP,
+		CancelReason:        strings.ToLower(o.CancelReason),
+		Confirmed:           o.Confirmed,
+		CustomerLocale:      o.CustomerLocale,
+		SourceName:          o.SourceName,
+		SourceIdentifier:    o.SourceIdentifier,
+		PaymentGatewayNames: o.PaymentGatewayNames,
+		Tags:                strings.Join(o.Tags, ", "),
+	}
+	order.TotalWeight, _ = strconv.Atoi(o.TotalWeight)
+	// Like the REST API, the order number starts at 1001 while the number starts at 1
+	order.Number = o.Number
+	order.OrderNumber = o.Number + 1000
+	if o.Customer != nil {
+		order.Customer = &goshopify.Customer{ID: parseGID(o.Customer.ID)}
+	}
+	for _, code := range o.DiscountCodes {
+		order.DiscountCodes = append(order.DiscountCodes, goshopify.DiscountCode{Code: code})
+	}
+	order.TaxLines = toTaxLines(o.TaxLines)
+	for _, attribute := range o.CustomAttributes {
+		order.NoteAttributes = append(order.NoteAttributes, goshopify.NoteAttribute{Name: attribute.Key, Value: attribute.Value})
+	}
+	if o.CustomerJourneySummary != nil && o.CustomerJourneySummary.FirstVisit != nil {
+		order.ReferringSite = o.CustomerJourneySummary.FirstVisit.ReferrerURL
+	}
+	return order
+}
+
+func toTaxLines(taxLines []bulkTaxLine) []goshopify.TaxLine {
+	var result []goshopify.TaxLine
+	for _, taxLine := range taxLines {
+		result = append(result, goshopify.TaxLine{
+			Title: taxLine.Title,
+			Rate:  taxLine.Rate,
+			Price: taxLine.PriceSet.amount(),
+		})
+	}
+	return result
+}
+
+func (s bulkShippingLine) toShippingLine() goshopify.ShippingLines {
+	return goshopify.ShippingLines{
+		ID:                parseGID(s.ID),
+		Title:             s.Title,
+		Code:              s.Code,
+		Source:            s.Source,
+		Phone:             s.Phone,
+		CarrierIdentifier: s.CarrierIdentifier,
+		DeliveryCategory:  s.DeliveryCategory,
+		Price:             s.OriginalPriceSet.amount(),
+		DiscountedPrice:   s.DiscountedPriceSet.amount(),
+		TaxLines:          toTaxLines(s.TaxLines),
+	}
+}
+
+func (l bulkLineItem) toLineItem() goshopify.LineItem {
+	lineItem := goshopify.LineItem{
+		ID:               parseGID(l.ID),
+		Name:             l.Name,
+		Title:            l.Title,
+		VariantTitle:     l.VariantTitle,
+		SKU:              l.SKU,
+		Vendor:           l.Vendor,
+		Quantity:         l.Quantity,
+		GiftCard:         l.IsGiftCard,
+		Taxable:          l.Taxable,
+		RequiresShipping: l.RequiresShipping,
+		Price:            l.OriginalUnitPriceSet.amount(),
+		TotalDiscount:    l.TotalDiscountSet.amount(),
+		ProductExists:    l.Product != nil,
+	}
+	if l.Product != nil {
+		lineItem.ProductID = parseGID(l.Product.ID)
+	}
+	if l.Variant != nil {
+		lineItem.VariantID = parseGID(l.Variant.ID)
+	}
+	return lineItem
+}
+
+// listOrdersBulk lists the orders with a bulk operation. The line items and the shipping
+// lines of an order follow it in the result, so each order is streamed once the next one starts.
+func listOrdersBulk(ctx context.Context, d *plugin.QueryData) (interface{}, error) {
+	if err := checkBulkOperationColumns(d, bulkOrderColumns); err != nil {
+		return nil, err
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_order.listOrdersBulk", "connection_error", err)
+		return nil, err
+	}
+
+	var order *goshopify.Order
+	err = client.RunBulkQuery(ctx, bulkOrdersQuery, func(object json.RawMessage, parentID string) (bool, error) {
+		if parentID != "" {
+			if order == nil || parseGID(parentID) != order.ID {
+				return false, fmt.Errorf("bulk operation: %s is not the current order", parentID)
+			}
+			// The line items and the shipping lines are told apart by the type in their ID
+			var child struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(object, &child); err != nil {
+				return false, err
+			}
+			if strings.HasPrefix(child.ID, "gid://shopify/ShippingLine/") {
+				var shippingLine bulkShippingLine
+				if err := json.Unmarshal(object, &shippingLine); err != nil {
+					return false, err
+				}
+				order.ShippingLines = append(order.ShippingLines, shippingLine.toShippingLine())
+				return true, nil
+			}
+			var lineItem bulkLineItem
+			if err := json.Unmarshal(object, &lineItem); err != nil {
+				return false, err
+			}
+			order.LineItems = append(order.LineItems, lineItem.toLineItem())
+			return true, nil
+		}
+
+		if order != nil {
+			d.StreamListItem(ctx, *order)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				order = nil
+				return false, nil
+			}
+		}
+
+		var result bulkOrder
+		if err := json.Unmarshal(object, &result); err != nil {
+			return false, err
+		}
+		next := result.toOrder()
+		order = &next
+		return true, nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_order.listOrdersBulk", "api_error", err)
+		return nil, err
+	}
+
+	if order != nil {
+		d.StreamListItem(ctx, *order)
+	}
+
+	return nil, nil
+}
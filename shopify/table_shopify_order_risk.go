@@ -0,0 +1,187 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// OrderRisk represents the fraud risk assessment of a Shopify order.
+type OrderRisk struct {
+	ID              int64  `json:"id,omitempty"`
+	OrderID         int64  `json:"order_id,omitempty"`
+	CheckoutID      int64  `json:"checkout_id,omitempty"`
+	Source          string `json:"source,omitempty"`
+	Score           string `json:"score,omitempty"`
+	Recommendation  string `json:"recommendation,omitempty"`
+	Display         bool   `json:"display,omitempty"`
+	CauseCancel     bool   `json:"cause_cancel,omitempty"`
+	Message         string `json:"message,omitempty"`
+	MerchantMessage string `json:"merchant_message,omitempty"`
+}
+
+type OrderRisksResource struct {
+	Risks []OrderRisk `json:"risks"`
+}
+
+func tableShopifyOrderRisk(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_order_risk",
+		Description: "Shopify order risk is a fraud risk assessment of an order, with a recommendation on whether to fulfill or cancel it.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listOrderRiskOrders,
+			Hydrate:       listOrderRisks,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "order_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The order risk ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "order_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the order that the order risk belongs to.",
+				Transform:   transform.FromField("OrderID"),
+			},
+			{
+				Name:        "checkout_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the checkout that the order risk belongs to.",
+				Transform:   transform.FromField("CheckoutID"),
+			},
+			{
+				Name:        "recommendation",
+				Type:        proto.ColumnType_STRING,
+				Description: "The recommended action given to the merchant. Possible values are: cancel, investigate and accept.",
+			},
+			{
+				Name:        "score",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "A number between 0 and 1 that's assigned to the order. The closer the score is to 1, the more likely it is that the order is fraudulent.",
+				Transform:   transform.FromField("Score").Transform(transform.ToDouble),
+			},
+			{
+				Name:        "source",
+				Type:        proto.ColumnType_STRING,
+				Description: "The source of the order risk.",
+			},
+			{
+				Name:        "cause_cancel",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether this order risk is severe enough to force the cancellation of the order.",
+			},
+			{
+				Name:        "display",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the order risk is displayed on the order details page in the Shopify admin.",
+			},
+			{
+				Name:        "message",
+				Type:        proto.ColumnType_STRING,
+				Description: "The message that's displayed to the merchant to indicate the results of the fraud check.",
+			},
+			{
+				Name:        "merchant_message",
+				Type:        proto.ColumnType_STRING,
+				Description: "The message that's displayed to the merchant in the Shopify admin.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Message"),
+			},
+		}),
+	}
+}
+
+func listOrderRiskOrders(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Skip listing the orders if the order is known
+	if orderID := d.EqualsQuals["order_id"].GetInt64Value(); orderID != 0 {
+		d.StreamListItem(ctx, goshopify.Order{ID: orderID})
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_order_risk.listOrderRiskOrders", "connection_error", err)
+		return nil, err
+	}
+
+	// List the orders of any status, since fulfilled orders are usually archived.
+	// The API only accepts the limit and the fields along with a page_info, so the
+	// status is only set on the first page.
+	options := goshopify.OrderListOptions{
+		ListOptions: goshopify.ListOptions{
+			Limit:  250,
+			Fields: "id",
+		},
+		Status: "any",
+	}
+
+	for {
+		orders, paginator, err := conn.Order.ListWithPagination(options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_order_risk.listOrderRiskOrders", "api_error", err)
+			return nil, err
+		}
+
+		for _, order := range orders {
+			d.StreamListItem(ctx, order)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		options.ListOptions.PageInfo = paginator.NextPageOptions.PageInfo
+		options.Status = ""
+	}
+}
+
+func listOrderRisks(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	order := h.Item.(goshopify.Order)
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_order_risk.listOrderRisks", "connection_error", err)
+		return nil, err
+	}
+
+	result := new(OrderRisksResource)
+	err = conn.Get(fmt.Sprintf("orders/%d/risks.json", order.ID), result, nil)
+	if err != nil {
+		// The order doesn't exist, so it has no risks
+		var responseError goshopify.ResponseError
+		if errors.As(err, &responseError) && responseError.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("shopify_order_risk.listOrderRisks", "api_error", err)
+		return nil, err
+	}
+
+	for _, risk := range result.Risks {
+		d.StreamListItem(ctx, risk)
+
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,193 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Payout extends the go-shopify payout with the summary breakdown, which the
+// client doesn't decode.
+type Payout struct {
+	goshopify.Payout
+	Summary *PayoutSummary `json:"summary,omitempty"`
+}
+
+type PayoutSummary struct {
+	AdjustmentsFeeAmount      string `json:"adjustments_fee_amount,omitempty"`
+	AdjustmentsGrossAmount    string `json:"adjustments_gross_amount,omitempty"`
+	ChargesFeeAmount          string `json:"charges_fee_amount,omitempty"`
+	ChargesGrossAmount        string `json:"charges_gross_amount,omitempty"`
+	RefundsFeeAmount          string `json:"refunds_fee_amount,omitempty"`
+	RefundsGrossAmount        string `json:"refunds_gross_amount,omitempty"`
+	ReservedFundsFeeAmount    string `json:"reserved_funds_fee_amount,omitempty"`
+	ReservedFundsGrossAmount  string `json:"reserved_funds_gross_amount,omitempty"`
+	RetriedPayoutsFeeAmount   string `json:"retried_payouts_fee_amount,omitempty"`
+	RetriedPayoutsGrossAmount string `json:"retried_payouts_gross_amount,omitempty"`
+}
+
+type PayoutResource struct {
+	Payout *Payout `json:"payout"`
+}
+
+type PayoutsResource struct {
+	Payouts []Payout `json:"payouts"`
+}
+
+// paymentsUnavailableMessage is reported when the store doesn't use Shopify Payments
+const paymentsUnavailableMessage = "Shopify Payments data is not available for this store. The store must use Shopify Payments and the access token requires the read_shopify_payments_payouts or read_shopify_payments_disputes access scope"
+
+func tableShopifyPayout(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_payout",
+		Description: "Shopify payout is a transfer of money from Shopify Payments to the merchant's bank account.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getPayout,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listPayouts,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "status", Require: plugin.Optional},
+				{Name: "date", Require: plugin.Optional, Operators: []string{">", ">=", "<", "<=", "="}},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The payout ID.",
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The transfer status of the payout. Possible values are: scheduled, in_transit, paid, failed and canceled.",
+			},
+			{
+				Name:        "date",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date the payout was issued.",
+				Transform:   transform.FromField("Date.Time"),
+			},
+			{
+				Name:        "currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 currency code of the payout.",
+			},
+			{
+				Name:        "amount",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The total amount of the payout.",
+				Transform:   transform.FromField("Amount").Transform(transform.ToString).Transform(transform.ToDouble),
+			},
+			{
+				Name:        "summary",
+				Type:        proto.ColumnType_JSON,
+				Description: "The breakdown of the payout into charges, refunds, adjustments, reserved funds and retried payouts, with their gross and fee amounts.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Id").Transform(transform.ToString),
+			},
+		}),
+	}
+}
+
+func listPayouts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_payout.listPayouts", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := goshopify.PayoutsListOptions{
+		Limit:  250,
+		Status: goshopify.PayoutStatus(d.EqualsQualString("status")),
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < 250 {
+			options.Limit = int(*limit)
+		}
+	}
+
+	if d.Quals["date"] != nil {
+		for _, q := range d.Quals["date"].Quals {
+			date := &goshopify.OnlyDate{Time: q.Value.GetTimestampValue().AsTime()}
+			switch q.Operator {
+			case ">", ">=":
+				options.DateMin = date
+			case "<", "<=":
+				options.DateMax = date
+			case "=":
+				options.Date = date
+			}
+		}
+	}
+
+	for {
+		result := new(PayoutsResource)
+		paginator, err := conn.ListWithPagination("shopify_payments/payouts.json", result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_payout.listPayouts", "api_error", err)
+			if isUnavailableError(err) {
+				return nil, fmt.Errorf("%s: %v", paymentsUnavailableMessage, err)
+			}
+			return nil, err
+		}
+
+		for _, payout := range result.Payouts {
+			d.StreamListItem(ctx, payout)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		// The filters are encoded in the page_info cursor and can't be passed again
+		options = goshopify.PayoutsListOptions{
+			PageInfo: paginator.NextPageOptions.PageInfo,
+			Limit:    options.Limit,
+		}
+	}
+}
+
+func getPayout(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_payout.getPayout", "connection_error", err)
+		return nil, err
+	}
+
+	result := new(PayoutResource)
+	err = conn.Get(fmt.Sprintf("shopify_payments/payouts/%d.json", id), result, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_payout.getPayout", "api_error", err)
+		return nil, err
+	}
+
+	return result.Payout, nil
+}
@@ -0,0 +1,383 @@
+package shopify
+
+import (
+	"context"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// PriceList represents the prices of the products in a market, either adjusted
+// from the base prices or fixed per variant.
+type PriceList struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Currency         string `json:"currency"`
+	FixedPricesCount int    `json:"fixedPricesCount"`
+	Parent           *struct {
+		Adjustment struct {
+			Type  string  `json:"type"`
+			Value float64 `json:"value"`
+		} `json:"adjustment"`
+	} `json:"parent"`
+	Catalog *struct {
+		ID      string `json:"id"`
+		Title   string `json:"title"`
+		Markets *struct {
+			Nodes    []PriceListMarket `json:"nodes"`
+			PageInfo GraphQLPageInfo   `json:"pageInfo"`
+		} `json:"markets"`
+	} `json:"catalog"`
+}
+
+// PriceListMarket is a market a price list applies to
+type PriceListMarket struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PriceListPrice is the price of a variant in a price list
+type PriceListPrice struct {
+	PriceList      PriceList     `json:"priceList"`
+	OriginType     string        `json:"originType"`
+	Price          *graphQLMoney `json:"price"`
+	CompareAtPrice *graphQLMoney `json:"compareAtPrice"`
+	Variant        *struct {
+		ID          string `json:"id"`
+		SKU         string `json:"sku"`
+		DisplayName string `json:"displayName"`
+		Product     *struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"product"`
+	} `json:"variant"`
+}
+
+const priceListFields = `id
+name
+currency
+fixedPricesCount
+parent {
+  adjustment {
+    type
+    value
+  }
+}
+catalog {
+  id
+  title
+  ... on MarketCatalog {
+    markets(first: 25) {
+      nodes {
+        id
+        name
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const listPriceListsQuery = `query($first: Int!, $after: String) {
+  priceLists(first: $first, after: $after) {
+    nodes {
+      ` + priceListFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getPriceListQuery = `query($id: ID!) {
+  priceList(id: $id) {
+    ` + priceListFields + `
+  }
+}`
+
+const listPriceListCatalogMarketsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  catalog(id: $id) {
+    ... on MarketCatalog {
+      markets(first: $first, after: $after) {
+        nodes {
+          id
+          name
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+const listPriceListPricesQuery = `query($id: ID!, $originType: PriceListPriceOriginType, $first: Int!, $after: String) {
+  priceList(id: $id) {
+    prices(originType: $originType, first: $first, after: $after) {
+      nodes {
+        originType
+        price {
+          amount
+          currencyCode
+        }
+        compareAtPrice {
+          amount
+          currencyCode
+        }
+        variant {
+          id
+          sku
+          displayName
+          product {
+            id
+            title
+          }
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const (
+	// Each price list costs 31 points: the price list, its parent and adjustment, its
+	// catalog, and the 2+25 points of the first 25 markets of the catalog. 25 of them cost
+	// 2+25*31 = 777 points, below the max of 1000 points of a query.
+	priceListPageSize = 25
+
+	// Each price costs 5 points: the price, its price and compare at price, the variant and
+	// its product. 150 of them cost 2+150*5+1 = 753 points, below the max of 1000 points of a query.
+	priceListPricePageSize = 150
+)
+
+func tableShopifyPriceList(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_price_list",
+		Description: "Shopify price list is the prices of the product variants in a market, either fixed per variant or adjusted from the base prices.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listPriceLists,
+			Hydrate:       listPriceListPrices,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "price_list_id", Require: plugin.Optional},
+				{Name: "origin_type", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "price_list_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the price list.",
+				Transform:   transform.FromField("PriceList.ID"),
+			},
+			{
+				Name:        "price_list_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the price list.",
+				Transform:   transform.FromField("PriceList.Name"),
+			},
+			{
+				Name:        "currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 code of the currency of the price list.",
+				Transform:   transform.FromField("PriceList.Currency"),
+			},
+			{
+				Name:        "variant_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the product variant.",
+				Transform:   transform.FromField("Variant.ID").Transform(gidToID),
+			},
+			{
+				Name:        "product_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the product.",
+				Transform:   transform.FromField("Variant.Product.ID").Transform(gidToID),
+			},
+			{
+				Name:        "product_title",
+				Type:        proto.ColumnType_STRING,
+				Description: "The title of the product.",
+				Transform:   transform.FromField("Variant.Product.Title"),
+			},
+			{
+				Name:        "variant_display_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The display name of the product variant, i.e. the product title and the variant title.",
+				Transform:   transform.FromField("Variant.DisplayName"),
+			},
+			{
+				Name:        "sku",
+				Type:        proto.ColumnType_STRING,
+				Description: "The SKU of the product variant.",
+				Transform:   transform.FromField("Variant.SKU"),
+			},
+			{
+				Name:        "price",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The price of the product variant in the price list.",
+				Transform:   transform.FromField("Price.Amount").Transform(convertPrice),
+			},
+			{
+				Name:        "compare_at_price",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The compare-at price of the product variant in the price list.",
+				Transform:   transform.FromField("CompareAtPrice.Amount").Transform(convertPrice),
+			},
+			{
+				Name:        "origin_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "Whether the price is fixed for the variant or adjusted from the base price. Possible values are: fixed and relative.",
+				Transform:   transform.FromField("OriginType").Transform(transform.ToLower),
+			},
+			{
+				Name:        "adjustment_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of the adjustment applied to the base prices, e.g. percentage_decrease or percentage_increase.",
+				Transform:   transform.FromField("PriceList.Parent.Adjustment.Type").Transform(transform.ToLower),
+			},
+			{
+				Name:        "adjustment_value",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The percentage of the adjustment applied to the base prices.",
+				Transform:   transform.FromField("PriceList.Parent.Adjustment.Value"),
+			},
+			{
+				Name:        "fixed_prices_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of fixed prices in the price list.",
+				Transform:   transform.FromField("PriceList.FixedPricesCount"),
+			},
+			{
+				Name:        "catalog_title",
+				Type:        proto.ColumnType_STRING,
+				Description: "The title of the catalog the price list belongs to.",
+				Transform:   transform.FromField("PriceList.Catalog.Title"),
+			},
+			{
+				Name:        "markets",
+				Type:        proto.ColumnType_JSON,
+				Description: "The markets the price list applies to.",
+				Transform:   transform.FromField("PriceList.Catalog.Markets.Nodes"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Variant.DisplayName"),
+			},
+		}),
+	}
+}
+
+func listPriceLists(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_price_list.listPriceLists", "connection_error", err)
+		return nil, err
+	}
+
+	// Get the price list only if the price list is known
+	if priceListID := d.EqualsQualString("price_list_id"); priceListID != "" {
+		var result struct {
+			PriceList *PriceList `json:"priceList"`
+		}
+		_, err = client.Query(ctx, getPriceListQuery, map[string]interface{}{"id": priceListID}, &result)
+		if err == nil && result.PriceList != nil {
+			err = listPriceListRemainingMarkets(ctx, client, result.PriceList)
+		}
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_price_list.listPriceLists", "api_error", err)
+			return nil, err
+		}
+
+		// The API returns null if the price list doesn't exist
+		if result.PriceList != nil {
+			d.StreamListItem(ctx, *result.PriceList)
+		}
+		return nil, nil
+	}
+
+	variables := map[string]interface{}{
+		"first": priceListPageSize,
+	}
+	var marketsErr error
+	err = listGraphQLNodes(ctx, client, listPriceListsQuery, variables, []string{"priceLists"}, func(priceList PriceList) bool {
+		if marketsErr = listPriceListRemainingMarkets(ctx, client, &priceList); marketsErr != nil {
+			return false
+		}
+		d.StreamListItem(ctx, priceList)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err == nil {
+		err = marketsErr
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_price_list.listPriceLists", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// listPriceListRemainingMarkets lists all the markets of the catalog of a price list, if there
+// are more than the first page of them returned with the price list
+func listPriceListRemainingMarkets(ctx context.Context, client *GraphQLClient, priceList *PriceList) error {
+	catalog := priceList.Catalog
+	if catalog == nil || catalog.Markets == nil || !catalog.Markets.PageInfo.HasNextPage {
+		return nil
+	}
+
+	var markets []PriceListMarket
+	err := listGraphQLNodes(ctx, client, listPriceListCatalogMarketsQuery, map[string]interface{}{"id": catalog.ID}, []string{"catalog", "markets"}, func(market PriceListMarket) bool {
+		markets = append(markets, market)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	catalog.Markets.Nodes = markets
+	return nil
+}
+
+func listPriceListPrices(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	priceList := h.Item.(PriceList)
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_price_list.listPriceListPrices", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"id":    priceList.ID,
+		"first": graphQLPageSize(d, priceListPricePageSize),
+	}
+	if originType := d.EqualsQualString("origin_type"); originType != "" {
+		variables["originType"] = strings.ToUpper(originType)
+	}
+	err = listGraphQLNodes(ctx, client, listPriceListPricesQuery, variables, []string{"priceList", "prices"}, func(price PriceListPrice) bool {
+		price.PriceList = priceList
+		d.StreamListItem(ctx, price)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_price_list.listPriceListPrices", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
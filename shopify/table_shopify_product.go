@@ -2,8 +2,13 @@ package shopify
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/shopspring/decimal"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -145,6 +150,11 @@ func listProducts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 		return nil, err
 	}
 
+	// Read the whole table with a bulk operation if they are enabled in the connection config
+	if useBulkOperations(d) {
+		return listProductsBulk(ctx, d)
+	}
+
 	// max limit defined by the api is 250
 	options := goshopify.ListOptions{}
 
@@ -222,3 +232,327 @@ func listProductMetafields(ctx context.Context, d *plugin.QueryData, h *plugin.H
 
 	return meta, nil
 }
+
+// bulkProductsQuery selects the products and their variants for a bulk operation
+const bulkProductsQuery = `{
+  products {
+    edges {
+      node {
+        id
+        title
+        descriptionHtml
+        vendor
+        productType
+        handle
+        createdAt
+        updatedAt
+        publishedAt
+        tags
+        status
+        templateSuffix
+        seo {
+          title
+          description
+        }
+        options {
+          id
+          name
+          position
+          values
+        }
+        variants {
+          edges {
+            node {
+              id
+              title
+              sku
+              position
+              inventoryPolicy
+              price
+              compareAtPrice
+              barcode
+              taxable
+              inventoryQuantity
+              createdAt
+              updatedAt
+              selectedOptions {
+                name
+                value
+              }
+              inventoryItem {
+                id
+                tracked
+                requiresShipping
+                measurement {
+                  weight {
+                    unit
+                    value
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// bulkProduct is a product in the result of a bulk operation
+type bulkProduct struct {
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	DescriptionHTML string     `json:"descriptionHtml"`
+	Vendor          string     `json:"vendor"`
+	ProductType     string     `json:"productType"`
+	Handle          string     `json:"handle"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	UpdatedAt       *time.Time `json:"updatedAt"`
+	PublishedAt     *time.Time `json:"publishedAt"`
+	Tags            []string   `json:"tags"`
+	Status          string     `json:"status"`
+	TemplateSuffix  string     `json:"templateSuffix"`
+	SEO             struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	} `json:"seo"`
+	Options []struct {
+		ID       string   `json:"id"`
+		Name     string   `json:"name"`
+		Position int      `json:"position"`
+		Values   []string `json:"values"`
+	} `json:"options"`
+}
+
+// bulkVariant is a product variant in the result of a bulk operation
+type bulkVariant struct {
+	ID                string           `json:"id"`
+	Title             string           `json:"title"`
+	SKU               string           `json:"sku"`
+	Position          int              `json:"position"`
+	InventoryPolicy   string           `json:"inventoryPolicy"`
+	Price             *decimal.Decimal `json:"price"`
+	CompareAtPrice    *decimal.Decimal `json:"compareAtPrice"`
+	Barcode           string           `json:"barcode"`
+	Taxable           bool             `json:"taxable"`
+	InventoryQuantity int              `json:"inventoryQuantity"`
+	CreatedAt         *time.Time       `json:"createdAt"`
+	UpdatedAt         *time.Time       `json:"updatedAt"`
+	SelectedOptions   []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"selectedOptions"`
+	InventoryItem *struct {
+		ID               string `json:"id"`
+		Tracked          bool   `json:"tracked"`
+		RequiresShipping bool   `json:"requiresShipping"`
+		Measurement      *struct {
+			Weight *struct {
+				Unit  string           `json:"unit"`
+				Value *decimal.Decimal `json:"value"`
+			} `json:"weight"`
+		} `json:"measurement"`
+	} `json:"inventoryItem"`
+}
+
+// bulkWeightUnits maps the GraphQL weight units to the REST ones
+var bulkWeightUnits = map[string]string{
+	"GRAMS":     "g",
+	"KILOGRAMS": "kg",
+	"OUNCES":    "oz",
+	"POUNDS":    "lb",
+}
+
+// bulkGramsPerWeightUnit is the number of grams in each of the GraphQL weight units
+var bulkGramsPerWeightUnit = map[string]float64{
+	"GRAMS":     1,
+	"KILOGRAMS": 1000,
+	"OUNCES":    28.349523125,
+	"POUNDS":    453.59237,
+}
+
+// bulkProductColumns are the columns of the products listed with a bulk operation.
+// The other columns aren't available in the GraphQL Admin API.
+var bulkProductColumns = []string{
+	"id",
+	"product_title",
+	"body_html",
+	"vendor",
+	"product_type",
+	"handle",
+	"created_at",
+	"updated_at",
+	"published_at",
+	"tags",
+	"status",
+	"options",
+	"template_suffix",
+	"metafields_global_title_tag",
+	"metafields_global_description_tag",
+	"metafields",
+	"admin_graphql_api_id",
+	"title",
+}
+
+// bulkProductVariantColumns are the columns of the product variants listed with a
+// bulk operation. The other columns aren't available in the GraphQL Admin API.
+var bulkProductVariantColumns = []string{
+	"id",
+	"product_id",
+	"product_title",
+	"sku",
+	"position",
+	"grams",
+	"inventory_policy",
+	"price",
+	"compare_at_price",
+	"inventory_management",
+	"inventory_item_id",
+	"option1",
+	"option2",
+	"option3",
+	"created_at",
+	"updated_at",
+	"taxable",
+	"barcode",
+	"inventory_quantity",
+	"weight",
+	"weight_unit",
+	"requires_shipping",
+	"admin_graphql_api_id",
+	"title",
+}
+
+func (p bulkProduct) toProduct() goshopify.Product {
+	product := goshopify.Product{
+		ID:                             parseGID(p.ID),
+		Title:                          p.Title,
+		BodyHTML:                       p.DescriptionHTML,
+		Vendor:                         p.Vendor,
+		ProductType:                    p.ProductType,
+		Handle:                         p.Handle,
+		CreatedAt:                      p.CreatedAt,
+		UpdatedAt:                      p.UpdatedAt,
+		PublishedAt:                    p.PublishedAt,
+		Tags:                           strings.Join(p.Tags, ", "),
+		Status:                         strings.ToLower(p.Status),
+		TemplateSuffix:                 p.TemplateSuffix,
+		MetafieldsGlobalTitleTag:       p.SEO.Title,
+		MetafieldsGlobalDescriptionTag: p.SEO.Description,
+		AdminGraphqlAPIID:              p.ID,
+	}
+	for _, option := range p.Options {
+		product.Options = append(product.Options, goshopify.ProductOption{
+			ID:        parseGID(option.ID),
+			ProductID: product.ID,
+			Name:      option.Name,
+			Position:  option.Position,
+			Values:    option.Values,
+		})
+	}
+	return product
+}
+
+func (v bulkVariant) toVariant(productID int64) goshopify.Variant {
+	variant := goshopify.Variant{
+		ID:                parseGID(v.ID),
+		ProductID:         productID,
+		Title:             v.Title,
+		Sku:               v.SKU,
+		Position:          v.Position,
+		InventoryPolicy:   strings.ToLower(v.InventoryPolicy),
+		Price:             v.Price,
+		CompareAtPrice:    v.CompareAtPrice,
+		Barcode:           v.Barcode,
+		Taxable:           v.Taxable,
+		InventoryQuantity: v.InventoryQuantity,
+		CreatedAt:         v.CreatedAt,
+		UpdatedAt:         v.UpdatedAt,
+		AdminGraphqlAPIID: v.ID,
+	}
+	// The REST API has a field for each of the up to three options
+	options := []*string{&variant.Option1, &variant.Option2, &variant.Option3}
+	for i, option := range v.SelectedOptions {
+		if i < len(options) {
+			*options[i] = option.Value
+		}
+	}
+	if item := v.InventoryItem; item != nil {
+		variant.InventoryItemId = parseGID(item.ID)
+		variant.RequireShipping = item.RequiresShipping
+		if item.Tracked {
+			variant.InventoryManagement = "shopify"
+		}
+		if item.Measurement != nil && item.Measurement.Weight != nil {
+			weight := item.Measurement.Weight
+			variant.Weight = weight.Value
+			variant.WeightUnit = bulkWeightUnits[weight.Unit]
+			if weight.Value != nil {
+				variant.Grams = int(weight.Value.Mul(decimal.NewFromFloat(bulkGramsPerWeightUnit[weight.Unit])).Round(0).IntPart())
+			}
+		}
+	}
+	return variant
+}
+
+// listProductsBulk lists the products with a bulk operation. The variants of a
+// product follow it in the result, so each product is streamed once the next one starts.
+func listProductsBulk(ctx context.Context, d *plugin.QueryData) (interface{}, error) {
+	// The product variant table lists the products with their variants
+	columns := bulkProductColumns
+	if d.Table.Name == "shopify_product_variant" {
+		columns = bulkProductVariantColumns
+	}
+	if err := checkBulkOperationColumns(d, columns); err != nil {
+		return nil, err
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_product.listProductsBulk", "connection_error", err)
+		return nil, err
+	}
+
+	var product *goshopify.Product
+	err = client.RunBulkQuery(ctx, bulkProductsQuery, func(object json.RawMessage, parentID string) (bool, error) {
+		if parentID != "" {
+			if product == nil || parseGID(parentID) != product.ID {
+				return false, fmt.Errorf("bulk operation: %s is not the current product", parentID)
+			}
+			var variant bulkVariant
+			if err := json.Unmarshal(object, &variant); err != nil {
+				return false, err
+			}
+			product.Variants = append(product.Variants, variant.toVariant(product.ID))
+			return true, nil
+		}
+
+		if product != nil {
+			d.StreamListItem(ctx, *product)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				product = nil
+				return false, nil
+			}
+		}
+
+		var result bulkProduct
+		if err := json.Unmarshal(object, &result); err != nil {
+			return false, err
+		}
+		next := result.toProduct()
+		product = &next
+		return true, nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_product.listProductsBulk", "api_error", err)
+		return nil, err
+	}
+
+	if product != nil {
+		d.StreamListItem(ctx, *product)
+	}
+
+	return nil, nil
+}
@@ -183,12 +183,16 @@ func listProductVariants(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		plugin.Logger(ctx).Error("shopify_product_variant.listProductVariants", "connection_error", err)
 		return nil, err
 	}
-	id := h.Item.(goshopify.Product).ID
+	product := h.Item.(goshopify.Product)
 
-	variants, err := conn.Variant.List(id, nil)
-	if err != nil {
-		plugin.Logger(ctx).Error("shopify_product_variant.listProductVariants", "api_error", err)
-		return nil, err
+	// The products listed with a bulk operation already include their variants
+	variants := product.Variants
+	if !useBulkOperations(d) {
+		variants, err = conn.Variant.List(product.ID, nil)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_product_variant.listProductVariants", "api_error", err)
+			return nil, err
+		}
 	}
 
 	for _, variant := range variants {
@@ -0,0 +1,123 @@
+package shopify
+
+import (
+	"context"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type Province struct {
+	CountryCode string
+	Province    goshopify.ShippingProvince
+}
+
+func tableShopifyProvince(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_province",
+		Description: "Shopify province is a sub-region of a country, such as a state or province, with the tax rate that is applied to orders shipped there.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listCountries,
+			Hydrate:       listProvinces,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "country_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The province ID.",
+				Transform:   transform.FromField("Province.ID"),
+			},
+			{
+				Name:        "country_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the country that the province belongs to.",
+				Transform:   transform.FromField("Province.CountryID"),
+			},
+			{
+				Name:        "country_code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The two-letter code of the country that the province belongs to.",
+				Transform:   transform.FromField("CountryCode"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The full name of the province.",
+				Transform:   transform.FromField("Province.Name"),
+			},
+			{
+				Name:        "code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The standard abbreviation of the province.",
+				Transform:   transform.FromField("Province.Code"),
+			},
+			{
+				Name:        "tax",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The sales tax rate applied to orders made by customers from the province.",
+				Transform:   transform.FromField("Province.Tax").Transform(convertPrice),
+			},
+			{
+				Name:        "tax_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the tax for the province.",
+				Transform:   transform.FromField("Province.TaxName"),
+			},
+			{
+				Name:        "tax_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The tax type. Possible values are: null, normal, harmonized and compounded.",
+				Transform:   transform.FromField("Province.TaxType"),
+			},
+			{
+				Name:        "tax_percentage",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The tax rate of the province, as a percentage.",
+				Transform:   transform.FromField("Province.TaxPercentage").Transform(convertPrice),
+			},
+			{
+				Name:        "shipping_zone_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the shipping zone that the province belongs to.",
+				Transform:   transform.FromField("Province.ShippingZoneID"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Province.Name"),
+			},
+		}),
+	}
+}
+
+func listProvinces(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	country := h.Item.(goshopify.ShippingCountry)
+
+	// Skip the countries that do not match the country_id qual
+	countryID := d.EqualsQuals["country_id"].GetInt64Value()
+	if countryID != 0 && countryID != country.ID {
+		return nil, nil
+	}
+
+	for _, province := range country.Provinces {
+		if province.CountryID == 0 {
+			province.CountryID = country.ID
+		}
+		d.StreamListItem(ctx, Province{
+			CountryCode: country.Code,
+			Province:    province,
+		})
+
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
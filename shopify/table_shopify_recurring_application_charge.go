@@ -0,0 +1,189 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableShopifyRecurringApplicationCharge(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_recurring_application_charge",
+		Description: "Shopify recurring application charge is a subscription charge that an app makes to a merchant every billing cycle.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getRecurringApplicationCharge,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listRecurringApplicationCharges,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The recurring application charge ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the recurring application charge.",
+			},
+			{
+				Name:        "status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The status of the recurring charge. Possible values are: pending, accepted, active, declined, expired, frozen and cancelled.",
+			},
+			{
+				Name:        "price",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The price of the recurring application charge.",
+				Transform:   transform.FromField("Price").Transform(convertPrice),
+			},
+			{
+				Name:        "capped_amount",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The limit a customer can be charged for usage based billing.",
+				Transform:   transform.FromField("CappedAmount").Transform(convertPrice),
+			},
+			{
+				Name:        "balance_used",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The amount of usage charges billed in the current billing cycle.",
+				Transform:   transform.FromField("BalanceUsed").Transform(convertPrice),
+			},
+			{
+				Name:        "balance_remaining",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The amount left before the capped amount is reached in the current billing cycle.",
+				Transform:   transform.FromField("BalanceRemaining").Transform(convertPrice),
+			},
+			{
+				Name:        "terms",
+				Type:        proto.ColumnType_STRING,
+				Description: "The terms and conditions of usage based billing charges.",
+			},
+			{
+				Name:        "trial_days",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of days that the customer is eligible for a free trial.",
+			},
+			{
+				Name:        "trial_ends_on",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the free trial ends.",
+			},
+			{
+				Name:        "billing_on",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the customer will be billed.",
+			},
+			{
+				Name:        "activated_on",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the customer activated the recurring application charge.",
+			},
+			{
+				Name:        "cancelled_on",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the merchant canceled their recurring application charge.",
+			},
+			{
+				Name:        "test",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the recurring application charge is a test transaction.",
+			},
+			{
+				Name:        "risk_level",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The risk level of the recurring application charge.",
+				Transform:   transform.FromField("RiskLevel").Transform(convertPrice),
+			},
+			{
+				Name:        "api_client_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the app that created the recurring application charge.",
+				Transform:   transform.FromField("APIClientID"),
+			},
+			{
+				Name:        "return_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The URL where the merchant is redirected after accepting the charge.",
+				Transform:   transform.FromField("ReturnURL"),
+			},
+			{
+				Name:        "confirmation_url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The URL where the merchant accepts or declines the recurring application charge.",
+				Transform:   transform.FromField("ConfirmationURL"),
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the recurring application charge was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the recurring application charge was last updated.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listRecurringApplicationCharges(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_recurring_application_charge.listRecurringApplicationCharges", "connection_error", err)
+		return nil, err
+	}
+
+	charges, err := conn.RecurringApplicationCharge.List(nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_recurring_application_charge.listRecurringApplicationCharges", "api_error", err)
+		return nil, err
+	}
+
+	for _, charge := range charges {
+		d.StreamListItem(ctx, charge)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+func getRecurringApplicationCharge(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_recurring_application_charge.getRecurringApplicationCharge", "connection_error", err)
+		return nil, err
+	}
+
+	result, err := conn.RecurringApplicationCharge.Get(id, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_recurring_application_charge.getRecurringApplicationCharge", "api_error", err)
+		return nil, err
+	}
+
+	return result, nil
+}
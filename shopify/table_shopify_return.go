@@ -0,0 +1,363 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Return represents the items of an order a customer sends back, and how they are handled.
+type Return struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Status        string     `json:"status"`
+	TotalQuantity int        `json:"totalQuantity"`
+	CreatedAt     *time.Time `json:"createdAt"`
+	ClosedAt      *time.Time `json:"closedAt"`
+	Order         *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"order"`
+	Decline *struct {
+		Reason string `json:"reason"`
+		Note   string `json:"note"`
+	} `json:"decline"`
+}
+
+const returnFields = `id
+name
+status
+totalQuantity
+createdAt
+closedAt
+order {
+  id
+  name
+}
+decline {
+  reason
+  note
+}`
+
+const listReturnOrdersQuery = `query($first: Int!, $after: String) {
+  orders(first: $first, after: $after, query: "-return_status:no_return") {
+    nodes {
+      id
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const listReturnsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  order(id: $id) {
+    returns(first: $first, after: $after) {
+      nodes {
+        ` + returnFields + `
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const getReturnQuery = `query($id: ID!) {
+  return(id: $id) {
+    ` + returnFields + `
+  }
+}`
+
+const listReturnLineItemsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  return(id: $id) {
+    returnLineItems(first: $first, after: $after) {
+      nodes {
+        id
+        quantity
+        refundableQuantity
+        refundedQuantity
+        returnReason
+        returnReasonNote
+        customerNote
+        ... on ReturnLineItem {
+          fulfillmentLineItem {
+            lineItem {
+              id
+              name
+              sku
+              product {
+                id
+              }
+              variant {
+                id
+              }
+            }
+          }
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// Each line item costs 5 points: the return line item, its fulfillment line item, the
+// line item of the order, the product and the variant. 150 of them cost 2+150*5+1 = 753
+// points, below the max of 1000 points of a query.
+const returnLineItemPageSize = 150
+
+const listReturnReverseFulfillmentOrdersQuery = `query($id: ID!, $first: Int!, $after: String) {
+  return(id: $id) {
+    reverseFulfillmentOrders(first: $first, after: $after) {
+      nodes {
+        id
+        status
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// returnOrder is an order that has returns
+type returnOrder struct {
+	ID string `json:"id"`
+}
+
+func tableShopifyReturn(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_return",
+		Description: "Shopify return is a request of a customer to send back some items of an order, and the way it is handled.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getReturn,
+		},
+		List: &plugin.ListConfig{
+			ParentHydrate: listReturnOrders,
+			Hydrate:       listReturns,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "order_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the return.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the return, e.g. #1001-R1.",
+			},
+			{
+				Name:        "order_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the order the return belongs to.",
+				Transform:   transform.FromField("Order.ID").Transform(gidToID),
+			},
+			{
+				Name:        "order_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the order the return belongs to.",
+				Transform:   transform.FromField("Order.Name"),
+			},
+			{
+				Name:        "status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The status of the return. Possible values are: requested, open, closed, declined and canceled.",
+				Transform:   transform.FromField("Status").Transform(transform.ToLower),
+			},
+			{
+				Name:        "total_quantity",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of items being returned.",
+			},
+			{
+				Name:        "decline_reason",
+				Type:        proto.ColumnType_STRING,
+				Description: "The reason the return request was declined, e.g. return_period_ended or final_sale.",
+				Transform:   transform.FromField("Decline.Reason").Transform(transform.ToLower),
+			},
+			{
+				Name:        "decline_note",
+				Type:        proto.ColumnType_STRING,
+				Description: "The note sent to the customer when the return request was declined.",
+				Transform:   transform.FromField("Decline.Note"),
+			},
+			{
+				Name:        "return_line_items",
+				Type:        proto.ColumnType_JSON,
+				Description: "The items being returned, with their quantities, return reasons and the line items of the order they come from.",
+				Hydrate:     listReturnLineItems,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "reverse_fulfillment_orders",
+				Type:        proto.ColumnType_JSON,
+				Description: "The reverse fulfillment orders of the return, i.e. the work to receive and process the returned items.",
+				Hydrate:     listReturnReverseFulfillmentOrders,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the return was created.",
+			},
+			{
+				Name:        "closed_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the return was closed.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listReturnOrders(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Skip listing the orders if the order is known
+	if orderID := d.EqualsQuals["order_id"].GetInt64Value(); orderID != 0 {
+		d.StreamListItem(ctx, returnOrder{ID: fmt.Sprintf("gid://shopify/Order/%d", orderID)})
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.listReturnOrders", "connection_error", err)
+		return nil, err
+	}
+
+	err = listGraphQLNodes(ctx, client, listReturnOrdersQuery, map[string]interface{}{}, []string{"orders"}, func(order returnOrder) bool {
+		d.StreamListItem(ctx, order)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.listReturnOrders", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func listReturns(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	order := h.Item.(returnOrder)
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.listReturns", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"id":    order.ID,
+		"first": graphQLPageSize(d, graphQLMaxPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listReturnsQuery, variables, []string{"order", "returns"}, func(r Return) bool {
+		d.StreamListItem(ctx, r)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.listReturns", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getReturn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.getReturn", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		Return *Return `json:"return"`
+	}
+	_, err = client.Query(ctx, getReturnQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.getReturn", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the return doesn't exist
+	if result.Return == nil {
+		return nil, nil
+	}
+
+	return *result.Return, nil
+}
+
+func listReturnLineItems(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(Return).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.listReturnLineItems", "connection_error", err)
+		return nil, err
+	}
+
+	lineItems := []interface{}{}
+	err = listGraphQLNodes(ctx, client, listReturnLineItemsQuery, map[string]interface{}{"id": id, "first": returnLineItemPageSize}, []string{"return", "returnLineItems"}, func(lineItem interface{}) bool {
+		lineItems = append(lineItems, lineItem)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.listReturnLineItems", "api_error", err)
+		return nil, err
+	}
+
+	return lineItems, nil
+}
+
+func listReturnReverseFulfillmentOrders(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(Return).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.listReturnReverseFulfillmentOrders", "connection_error", err)
+		return nil, err
+	}
+
+	orders := []interface{}{}
+	err = listGraphQLNodes(ctx, client, listReturnReverseFulfillmentOrdersQuery, map[string]interface{}{"id": id}, []string{"return", "reverseFulfillmentOrders"}, func(order interface{}) bool {
+		orders = append(orders, order)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_return.listReturnReverseFulfillmentOrders", "api_error", err)
+		return nil, err
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,376 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// SellingPlanGroup represents a group of selling plans, e.g. the subscription
+// options offered for a set of products.
+type SellingPlanGroup struct {
+	ID                   string        `json:"id"`
+	Name                 string        `json:"name"`
+	MerchantCode         string        `json:"merchantCode"`
+	AppID                string        `json:"appId"`
+	Description          string        `json:"description"`
+	Summary              string        `json:"summary"`
+	Options              []string      `json:"options"`
+	Position             *int          `json:"position"`
+	CreatedAt            *time.Time    `json:"createdAt"`
+	ProductsCount        *graphQLCount `json:"productsCount"`
+	ProductVariantsCount *graphQLCount `json:"productVariantsCount"`
+	SellingPlans         *struct {
+		Nodes []interface{} `json:"nodes"`
+	} `json:"sellingPlans"`
+}
+
+const sellingPlanPricingPolicyValueFields = `adjustmentValue {
+  ... on SellingPlanPricingPolicyPercentageValue {
+    percentage
+  }
+  ... on MoneyV2 {
+    amount
+    currencyCode
+  }
+}`
+
+const sellingPlanGroupFields = `id
+name
+merchantCode
+appId
+description
+summary
+options
+position
+createdAt
+productsCount {
+  count
+}
+productVariantsCount {
+  count
+}
+sellingPlans(first: 31) {
+  nodes {
+    id
+    name
+    description
+    options
+    position
+    category
+    createdAt
+    billingPolicy {
+      ... on SellingPlanRecurringBillingPolicy {
+        interval
+        intervalCount
+        minCycles
+        maxCycles
+      }
+      ... on SellingPlanFixedBillingPolicy {
+        remainingBalanceChargeTrigger
+        remainingBalanceChargeExactTime
+        remainingBalanceChargeTimeAfterCheckout
+        checkoutCharge {
+          type
+          value {
+            ... on SellingPlanCheckoutChargePercentageValue {
+              percentage
+            }
+            ... on MoneyV2 {
+              amount
+              currencyCode
+            }
+          }
+        }
+      }
+    }
+    deliveryPolicy {
+      ... on SellingPlanRecurringDeliveryPolicy {
+        interval
+        intervalCount
+        cutoff
+        intent
+        preAnchorBehavior
+      }
+      ... on SellingPlanFixedDeliveryPolicy {
+        fulfillmentTrigger
+        fulfillmentExactTime
+        cutoff
+        intent
+        preAnchorBehavior
+      }
+    }
+    pricingPolicies {
+      ... on SellingPlanFixedPricingPolicy {
+        adjustmentType
+        ` + sellingPlanPricingPolicyValueFields + `
+      }
+      ... on SellingPlanRecurringPricingPolicy {
+        afterCycle
+        adjustmentType
+        ` + sellingPlanPricingPolicyValueFields + `
+      }
+    }
+  }
+}`
+
+const listSellingPlanGroupsQuery = `query($first: Int!, $after: String) {
+  sellingPlanGroups(first: $first, after: $after) {
+    nodes {
+      ` + sellingPlanGroupFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getSellingPlanGroupQuery = `query($id: ID!) {
+  sellingPlanGroup(id: $id) {
+    ` + sellingPlanGroupFields + `
+  }
+}`
+
+const listSellingPlanGroupProductsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  sellingPlanGroup(id: $id) {
+    products(first: $first, after: $after) {
+      nodes {
+        id
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const listSellingPlanGroupProductVariantsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  sellingPlanGroup(id: $id) {
+    productVariants(first: $first, after: $after) {
+      nodes {
+        id
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// Each selling plan costs 7 points: the plan, its billing policy with the checkout charge
+// and its value, its delivery policy, and its pricing policies with their adjustment
+// value. Each group costs 222 points: the group, its 2 counts and the 2+31*7 points of its
+// selling plans. 4 of them cost 2+4*222 = 890 points, below the max of 1000 points of a query.
+const sellingPlanGroupPageSize = 4
+
+func tableShopifySellingPlanGroup(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_selling_plan_group",
+		Description: "Shopify selling plan group is a set of selling plans, e.g. subscribe and save options, offered for some products and variants.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSellingPlanGroup,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listSellingPlanGroups,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the selling plan group.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The buyer-facing name of the selling plan group.",
+			},
+			{
+				Name:        "merchant_code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The merchant-facing identifier of the selling plan group.",
+			},
+			{
+				Name:        "app_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The identifier of the app that created the selling plan group.",
+				Transform:   transform.FromField("AppID"),
+			},
+			{
+				Name:        "description",
+				Type:        proto.ColumnType_STRING,
+				Description: "The merchant-facing description of the selling plan group.",
+			},
+			{
+				Name:        "summary",
+				Type:        proto.ColumnType_STRING,
+				Description: "A summary of the policies of the selling plans of the group.",
+			},
+			{
+				Name:        "options",
+				Type:        proto.ColumnType_JSON,
+				Description: "The names of the options the selling plans of the group vary by, e.g. Delivery every.",
+			},
+			{
+				Name:        "position",
+				Type:        proto.ColumnType_INT,
+				Description: "The position of the selling plan group among the groups of a product.",
+			},
+			{
+				Name:        "products_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of products the selling plan group is offered for.",
+				Transform:   transform.FromField("ProductsCount.Count"),
+			},
+			{
+				Name:        "product_variants_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of product variants the selling plan group is offered for.",
+				Transform:   transform.FromField("ProductVariantsCount.Count"),
+			},
+			{
+				Name:        "selling_plans",
+				Type:        proto.ColumnType_JSON,
+				Description: "The selling plans of the group, with their billing, delivery and pricing policies.",
+				Transform:   transform.FromField("SellingPlans.Nodes"),
+			},
+			{
+				Name:        "product_ids",
+				Type:        proto.ColumnType_JSON,
+				Description: "The IDs of the products the selling plan group is offered for.",
+				Hydrate:     listSellingPlanGroupProductIDs,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "product_variant_ids",
+				Type:        proto.ColumnType_JSON,
+				Description: "The IDs of the product variants the selling plan group is offered for.",
+				Hydrate:     listSellingPlanGroupProductVariantIDs,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the selling plan group was created.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listSellingPlanGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_selling_plan_group.listSellingPlanGroups", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"first": graphQLPageSize(d, sellingPlanGroupPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listSellingPlanGroupsQuery, variables, []string{"sellingPlanGroups"}, func(group SellingPlanGroup) bool {
+		d.StreamListItem(ctx, group)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_selling_plan_group.listSellingPlanGroups", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getSellingPlanGroup(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_selling_plan_group.getSellingPlanGroup", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		SellingPlanGroup *SellingPlanGroup `json:"sellingPlanGroup"`
+	}
+	_, err = client.Query(ctx, getSellingPlanGroupQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_selling_plan_group.getSellingPlanGroup", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the selling plan group doesn't exist
+	if result.SellingPlanGroup == nil {
+		return nil, nil
+	}
+
+	return *result.SellingPlanGroup, nil
+}
+
+func listSellingPlanGroupProductIDs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(SellingPlanGroup).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_selling_plan_group.listSellingPlanGroupProductIDs", "connection_error", err)
+		return nil, err
+	}
+
+	ids, err := listSellingPlanGroupResourceIDs(ctx, client, listSellingPlanGroupProductsQuery, id, "products")
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_selling_plan_group.listSellingPlanGroupProductIDs", "api_error", err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func listSellingPlanGroupProductVariantIDs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(SellingPlanGroup).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_selling_plan_group.listSellingPlanGroupProductVariantIDs", "connection_error", err)
+		return nil, err
+	}
+
+	ids, err := listSellingPlanGroupResourceIDs(ctx, client, listSellingPlanGroupProductVariantsQuery, id, "productVariants")
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_selling_plan_group.listSellingPlanGroupProductVariantIDs", "api_error", err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// listSellingPlanGroupResourceIDs returns the numeric IDs of the nodes of a connection of a selling plan group
+func listSellingPlanGroupResourceIDs(ctx context.Context, client *GraphQLClient, query string, id string, connection string) ([]int64, error) {
+	ids := []int64{}
+	err := listGraphQLNodes(ctx, client, query, map[string]interface{}{"id": id}, []string{"sellingPlanGroup", connection}, func(node struct {
+		ID string `json:"id"`
+	}) bool {
+		ids = append(ids, parseGID(node.ID))
+		return true
+	})
+	return ids, err
+}
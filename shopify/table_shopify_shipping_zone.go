@@ -0,0 +1,121 @@
+package shopify
+
+import (
+	"context"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableShopifyShippingZone(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_shipping_zone",
+		Description: "Shopify shipping zone is a group of countries and provinces that share the same shipping rates.",
+		List: &plugin.ListConfig{
+			Hydrate: listShippingZones,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The shipping zone ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the shipping zone, specified by the user.",
+			},
+			{
+				Name:        "profile_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the shipping profile that the shipping zone belongs to.",
+				Transform:   transform.FromField("ProfileID"),
+			},
+			{
+				Name:        "location_group_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ID of the location group that the shipping zone belongs to.",
+				Transform:   transform.FromField("LocationGroupID"),
+			},
+			{
+				Name:        "countries",
+				Type:        proto.ColumnType_JSON,
+				Description: "The countries in the shipping zone, with their tax settings and provinces.",
+			},
+			{
+				Name:        "provinces",
+				Type:        proto.ColumnType_JSON,
+				Description: "The provinces of all the countries in the shipping zone.",
+				Transform:   transform.FromField("Countries").Transform(shippingZoneProvinces),
+			},
+			{
+				Name:        "weight_based_shipping_rates",
+				Type:        proto.ColumnType_JSON,
+				Description: "The shipping rates of the shipping zone that are based on the weight of the order.",
+			},
+			{
+				Name:        "price_based_shipping_rates",
+				Type:        proto.ColumnType_JSON,
+				Description: "The shipping rates of the shipping zone that are based on the subtotal of the order.",
+			},
+			{
+				Name:        "carrier_shipping_rate_providers",
+				Type:        proto.ColumnType_JSON,
+				Description: "The carrier services that provide calculated shipping rates for the shipping zone.",
+			},
+			{
+				Name:        "admin_graphql_api_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The admin graphql API ID of the shipping zone.",
+				Transform:   transform.FromField("AdminGraphqlAPIID"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+func listShippingZones(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_shipping_zone.listShippingZones", "connection_error", err)
+		return nil, err
+	}
+
+	zones, err := conn.ShippingZone.List()
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_shipping_zone.listShippingZones", "api_error", err)
+		return nil, err
+	}
+
+	for _, zone := range zones {
+		d.StreamListItem(ctx, zone)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// shippingZoneProvinces flattens the provinces of the countries in a shipping zone
+func shippingZoneProvinces(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	countries, ok := d.Value.([]goshopify.ShippingCountry)
+	if !ok {
+		return nil, nil
+	}
+	provinces := []goshopify.ShippingProvince{}
+	for _, country := range countries {
+		provinces = append(provinces, country.Provinces...)
+	}
+	return provinces, nil
+}
@@ -0,0 +1,382 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// SubscriptionContract represents an agreement of a customer to buy products on a recurring basis.
+type SubscriptionContract struct {
+	ID                string                      `json:"id"`
+	Status            string                      `json:"status"`
+	CurrencyCode      string                      `json:"currencyCode"`
+	LastPaymentStatus string                      `json:"lastPaymentStatus"`
+	NextBillingDate   *time.Time                  `json:"nextBillingDate"`
+	CreatedAt         *time.Time                  `json:"createdAt"`
+	UpdatedAt         *time.Time                  `json:"updatedAt"`
+	BillingPolicy     *SubscriptionContractPolicy `json:"billingPolicy"`
+	DeliveryPolicy    *SubscriptionContractPolicy `json:"deliveryPolicy"`
+	DeliveryPrice     *graphQLMoney               `json:"deliveryPrice"`
+	LinesCount        *graphQLCount               `json:"linesCount"`
+	Customer          *struct {
+		ID string `json:"id"`
+	} `json:"customer"`
+	OriginOrder *struct {
+		ID string `json:"id"`
+	} `json:"originOrder"`
+}
+
+// SubscriptionContractPolicy is how often a subscription contract is billed or delivered
+type SubscriptionContractPolicy struct {
+	Interval      string `json:"interval"`
+	IntervalCount int    `json:"intervalCount"`
+	MinCycles     *int   `json:"minCycles"`
+	MaxCycles     *int   `json:"maxCycles"`
+}
+
+const subscriptionContractFields = `id
+status
+currencyCode
+lastPaymentStatus
+nextBillingDate
+createdAt
+updatedAt
+billingPolicy {
+  interval
+  intervalCount
+  minCycles
+  maxCycles
+}
+deliveryPolicy {
+  interval
+  intervalCount
+}
+deliveryPrice {
+  amount
+  currencyCode
+}
+customer {
+  id
+}
+originOrder {
+  id
+}
+linesCount {
+  count
+}`
+
+const listSubscriptionContractsQuery = `query($first: Int!, $after: String) {
+  subscriptionContracts(first: $first, after: $after) {
+    nodes {
+      ` + subscriptionContractFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const getSubscriptionContractQuery = `query($id: ID!) {
+  subscriptionContract(id: $id) {
+    ` + subscriptionContractFields + `
+  }
+}`
+
+const listSubscriptionContractLinesQuery = `query($id: ID!, $first: Int!, $after: String) {
+  subscriptionContract(id: $id) {
+    lines(first: $first, after: $after) {
+      nodes {
+        id
+        productId
+        variantId
+        title
+        variantTitle
+        sku
+        quantity
+        sellingPlanId
+        sellingPlanName
+        currentPrice {
+          amount
+          currencyCode
+        }
+        lineDiscountedPrice {
+          amount
+          currencyCode
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const listSubscriptionBillingAttemptsQuery = `query($id: ID!, $first: Int!, $after: String) {
+  subscriptionContract(id: $id) {
+    billingAttempts(first: $first, after: $after) {
+      nodes {
+        id
+        createdAt
+        completedAt
+        originTime
+        ready
+        idempotencyKey
+        errorCode
+        errorMessage
+        order {
+          id
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// Each contract costs 7 points: the contract, its billing and delivery policies, its
+// delivery price, its customer, its origin order and its lines count. 100 of them cost
+// 2+100*7 = 702 points, below the max of 1000 points of a query.
+const subscriptionContractPageSize = 100
+
+func tableShopifySubscriptionContract(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_subscription_contract",
+		Description: "Shopify subscription contract is an agreement of a customer to buy some products on a recurring basis, according to a selling plan.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSubscriptionContract,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listSubscriptionContracts,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The GraphQL ID of the subscription contract.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The status of the subscription contract. Possible values are: active, paused, cancelled, expired and failed.",
+				Transform:   transform.FromField("Status").Transform(transform.ToLower),
+			},
+			{
+				Name:        "customer_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the customer the subscription contract belongs to.",
+				Transform:   transform.FromField("Customer.ID").Transform(gidToID),
+			},
+			{
+				Name:        "origin_order_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the order the subscription contract was created from.",
+				Transform:   transform.FromField("OriginOrder.ID").Transform(gidToID),
+			},
+			{
+				Name:        "currency_code",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 code of the currency the subscription contract is billed in.",
+			},
+			{
+				Name:        "next_billing_date",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the subscription contract is billed next.",
+			},
+			{
+				Name:        "last_payment_status",
+				Type:        proto.ColumnType_STRING,
+				Description: "The status of the last payment of the subscription contract, i.e. succeeded or failed.",
+				Transform:   transform.FromField("LastPaymentStatus").Transform(transform.ToLower),
+			},
+			{
+				Name:        "billing_interval",
+				Type:        proto.ColumnType_STRING,
+				Description: "The unit of the interval between the billings, i.e. day, week, month or year.",
+				Transform:   transform.FromField("BillingPolicy.Interval").Transform(transform.ToLower),
+			},
+			{
+				Name:        "billing_interval_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of intervals between the billings.",
+				Transform:   transform.FromField("BillingPolicy.IntervalCount"),
+			},
+			{
+				Name:        "billing_min_cycles",
+				Type:        proto.ColumnType_INT,
+				Description: "The minimum number of billing cycles the customer commits to.",
+				Transform:   transform.FromField("BillingPolicy.MinCycles"),
+			},
+			{
+				Name:        "billing_max_cycles",
+				Type:        proto.ColumnType_INT,
+				Description: "The maximum number of billing cycles, after which the subscription contract expires.",
+				Transform:   transform.FromField("BillingPolicy.MaxCycles"),
+			},
+			{
+				Name:        "delivery_interval",
+				Type:        proto.ColumnType_STRING,
+				Description: "The unit of the interval between the deliveries, i.e. day, week, month or year.",
+				Transform:   transform.FromField("DeliveryPolicy.Interval").Transform(transform.ToLower),
+			},
+			{
+				Name:        "delivery_interval_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of intervals between the deliveries.",
+				Transform:   transform.FromField("DeliveryPolicy.IntervalCount"),
+			},
+			{
+				Name:        "delivery_price",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The price of the delivery of each billing cycle.",
+				Transform:   transform.FromField("DeliveryPrice.Amount").Transform(convertPrice),
+			},
+			{
+				Name:        "lines_count",
+				Type:        proto.ColumnType_INT,
+				Description: "The number of lines of the subscription contract.",
+				Transform:   transform.FromField("LinesCount.Count"),
+			},
+			{
+				Name:        "lines",
+				Type:        proto.ColumnType_JSON,
+				Description: "The products of the subscription contract, with their quantities and prices.",
+				Hydrate:     listSubscriptionContractLines,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "billing_attempts",
+				Type:        proto.ColumnType_JSON,
+				Description: "The attempts to bill the subscription contract, with the resulting orders or errors.",
+				Hydrate:     listSubscriptionBillingAttempts,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the subscription contract was created.",
+			},
+			{
+				Name:        "updated_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the subscription contract was last updated.",
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("ID"),
+			},
+		}),
+	}
+}
+
+func listSubscriptionContracts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_subscription_contract.listSubscriptionContracts", "connection_error", err)
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"first": graphQLPageSize(d, subscriptionContractPageSize),
+	}
+	err = listGraphQLNodes(ctx, client, listSubscriptionContractsQuery, variables, []string{"subscriptionContracts"}, func(contract SubscriptionContract) bool {
+		d.StreamListItem(ctx, contract)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_subscription_contract.listSubscriptionContracts", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getSubscriptionContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQualString("id")
+
+	// check if the id is empty
+	if id == "" {
+		return nil, nil
+	}
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_subscription_contract.getSubscriptionContract", "connection_error", err)
+		return nil, err
+	}
+
+	var result struct {
+		SubscriptionContract *SubscriptionContract `json:"subscriptionContract"`
+	}
+	_, err = client.Query(ctx, getSubscriptionContractQuery, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_subscription_contract.getSubscriptionContract", "api_error", err)
+		return nil, err
+	}
+
+	// The API returns null if the subscription contract doesn't exist
+	if result.SubscriptionContract == nil {
+		return nil, nil
+	}
+
+	return *result.SubscriptionContract, nil
+}
+
+func listSubscriptionContractLines(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(SubscriptionContract).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_subscription_contract.listSubscriptionContractLines", "connection_error", err)
+		return nil, err
+	}
+
+	lines := []interface{}{}
+	err = listGraphQLNodes(ctx, client, listSubscriptionContractLinesQuery, map[string]interface{}{"id": id}, []string{"subscriptionContract", "lines"}, func(line interface{}) bool {
+		lines = append(lines, line)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_subscription_contract.listSubscriptionContractLines", "api_error", err)
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+func listSubscriptionBillingAttempts(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := h.Item.(SubscriptionContract).ID
+
+	client, err := connectGraphQL(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_subscription_contract.listSubscriptionBillingAttempts", "connection_error", err)
+		return nil, err
+	}
+
+	attempts := []interface{}{}
+	err = listGraphQLNodes(ctx, client, listSubscriptionBillingAttemptsQuery, map[string]interface{}{"id": id}, []string{"subscriptionContract", "billingAttempts"}, func(attempt interface{}) bool {
+		attempts = append(attempts, attempt)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_subscription_contract.listSubscriptionBillingAttempts", "api_error", err)
+		return nil, err
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,175 @@
+package shopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// TenderTransaction represents money passing between the merchant and a customer.
+type TenderTransaction struct {
+	ID              int64       `json:"id,omitempty"`
+	OrderID         int64       `json:"order_id,omitempty"`
+	Amount          string      `json:"amount,omitempty"`
+	Currency        string      `json:"currency,omitempty"`
+	UserID          int64       `json:"user_id,omitempty"`
+	Test            bool        `json:"test,omitempty"`
+	ProcessedAt     *time.Time  `json:"processed_at,omitempty"`
+	RemoteReference string      `json:"remote_reference,omitempty"`
+	PaymentDetails  interface{} `json:"payment_details,omitempty"`
+	PaymentMethod   string      `json:"payment_method,omitempty"`
+}
+
+type TenderTransactionsResource struct {
+	TenderTransactions []TenderTransaction `json:"tender_transactions"`
+}
+
+// TenderTransactionListOptions are the options for the tender transactions endpoint
+type TenderTransactionListOptions struct {
+	PageInfo       string    `url:"page_info,omitempty"`
+	Limit          int       `url:"limit,omitempty"`
+	ProcessedAtMin time.Time `url:"processed_at_min,omitempty"`
+	ProcessedAtMax time.Time `url:"processed_at_max,omitempty"`
+}
+
+func tableShopifyTenderTransaction(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_tender_transaction",
+		Description: "Shopify tender transaction is a record of money passing between the merchant and a customer, across all payment gateways.",
+		List: &plugin.ListConfig{
+			Hydrate: listTenderTransactions,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "processed_at", Require: plugin.Optional, Operators: []string{">", ">=", "<", "<=", "="}},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The tender transaction ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "order_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the order that the tender transaction belongs to.",
+				Transform:   transform.FromField("OrderID"),
+			},
+			{
+				Name:        "amount",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The amount of the tender transaction. Refunds have a negative amount.",
+				Transform:   transform.FromField("Amount").Transform(transform.ToDouble),
+			},
+			{
+				Name:        "currency",
+				Type:        proto.ColumnType_STRING,
+				Description: "The ISO 4217 currency code of the tender transaction.",
+			},
+			{
+				Name:        "payment_method",
+				Type:        proto.ColumnType_STRING,
+				Description: "The payment method of the tender transaction, e.g. credit_card, apple_pay, paypal or cash.",
+			},
+			{
+				Name:        "payment_details",
+				Type:        proto.ColumnType_JSON,
+				Description: "Information about the payment instrument used for the tender transaction.",
+			},
+			{
+				Name:        "processed_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the tender transaction was processed.",
+			},
+			{
+				Name:        "remote_reference",
+				Type:        proto.ColumnType_STRING,
+				Description: "The reference of the tender transaction in the payment provider.",
+			},
+			{
+				Name:        "test",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the tender transaction is a test transaction.",
+			},
+			{
+				Name:        "user_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the staff member who processed the tender transaction.",
+				Transform:   transform.FromField("UserID"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("ID").Transform(transform.ToString),
+			},
+		}),
+	}
+}
+
+func listTenderTransactions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_tender_transaction.listTenderTransactions", "connection_error", err)
+		return nil, err
+	}
+
+	// max limit defined by the API is 250
+	maxLimit := int64(250)
+	options := TenderTransactionListOptions{
+		Limit: int(maxLimit),
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < maxLimit {
+			options.Limit = int(*limit)
+		}
+	}
+
+	if d.Quals["processed_at"] != nil {
+		for _, q := range d.Quals["processed_at"].Quals {
+			processedAt := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case ">", ">=":
+				options.ProcessedAtMin = processedAt
+			case "<", "<=":
+				options.ProcessedAtMax = processedAt
+			case "=":
+				options.ProcessedAtMin = processedAt
+				options.ProcessedAtMax = processedAt
+			}
+		}
+	}
+
+	for {
+		result := new(TenderTransactionsResource)
+		paginator, err := conn.ListWithPagination("tender_transactions.json", result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_tender_transaction.listTenderTransactions", "api_error", err)
+			return nil, err
+		}
+
+		for _, transaction := range result.TenderTransactions {
+			d.StreamListItem(ctx, transaction)
+
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		// The filters are encoded in the page_info cursor and can't be passed again
+		options = TenderTransactionListOptions{
+			PageInfo: paginator.NextPageOptions.PageInfo,
+			Limit:    options.Limit,
+		}
+	}
+}
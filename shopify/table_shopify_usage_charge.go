@@ -0,0 +1,140 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type UsageCharge struct {
+	RecurringApplicationChargeID int64
+	UsageCharge                  goshopify.UsageCharge
+}
+
+func tableShopifyUsageCharge(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_usage_charge",
+		Description: "Shopify usage charge is a variable charge that an app adds to a recurring application charge, on top of its fixed price.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listUsageChargeRecurringApplicationCharges,
+			Hydrate:       listUsageCharges,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "recurring_application_charge_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The usage charge ID.",
+				Transform:   transform.FromField("UsageCharge.ID"),
+			},
+			{
+				Name:        "recurring_application_charge_id",
+				Type:        proto.ColumnType_INT,
+				Description: "The ID of the recurring application charge that the usage charge belongs to.",
+				Transform:   transform.FromField("RecurringApplicationChargeID"),
+			},
+			{
+				Name:        "description",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the usage charge.",
+				Transform:   transform.FromField("UsageCharge.Description"),
+			},
+			{
+				Name:        "price",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The price of the usage charge.",
+				Transform:   transform.FromField("UsageCharge.Price").Transform(convertPrice),
+			},
+			{
+				Name:        "balance_used",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The amount of usage charges billed in the current billing cycle.",
+				Transform:   transform.FromField("UsageCharge.BalanceUsed").Transform(convertPrice),
+			},
+			{
+				Name:        "balance_remaining",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The amount left before the capped amount is reached in the current billing cycle.",
+				Transform:   transform.FromField("UsageCharge.BalanceRemaining").Transform(convertPrice),
+			},
+			{
+				Name:        "billing_on",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the customer will be billed.",
+				Transform:   transform.FromField("UsageCharge.BillingOn"),
+			},
+			{
+				Name:        "risk_level",
+				Type:        proto.ColumnType_DOUBLE,
+				Description: "The risk level of the usage charge.",
+				Transform:   transform.FromField("UsageCharge.RiskLevel").Transform(convertPrice),
+			},
+			{
+				Name:        "created_at",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Description: "The date and time when the usage charge was created.",
+				Transform:   transform.FromField("UsageCharge.CreatedAt"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("UsageCharge.Description"),
+			},
+		}),
+	}
+}
+
+func listUsageChargeRecurringApplicationCharges(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	chargeID := d.EqualsQuals["recurring_application_charge_id"].GetInt64Value()
+
+	// List every recurring application charge if the charge isn't known
+	if chargeID == 0 {
+		return listRecurringApplicationCharges(ctx, d, h)
+	}
+
+	// Only the ID of the charge is needed to list its usage charges
+	d.StreamListItem(ctx, goshopify.RecurringApplicationCharge{ID: chargeID})
+	return nil, nil
+}
+
+func listUsageCharges(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	charge := h.Item.(goshopify.RecurringApplicationCharge)
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_usage_charge.listUsageCharges", "connection_error", err)
+		return nil, err
+	}
+
+	usageCharges, err := conn.UsageCharge.List(charge.ID, nil)
+	if err != nil {
+		// The charge of the recurring_application_charge_id qual doesn't exist, so it has no usage charges
+		var responseError goshopify.ResponseError
+		if errors.As(err, &responseError) && responseError.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("shopify_usage_charge.listUsageCharges", "api_error", err)
+		return nil, err
+	}
+
+	for _, usageCharge := range usageCharges {
+		d.StreamListItem(ctx, UsageCharge{
+			RecurringApplicationChargeID: charge.ID,
+			UsageCharge:                  usageCharge,
+		})
+
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
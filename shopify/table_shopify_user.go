@@ -0,0 +1,235 @@
+package shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	goshopify "github.com/bold-commerce/go-shopify/v3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// User represents a Shopify staff account.
+type User struct {
+	ID                   int64    `json:"id,omitempty"`
+	FirstName            string   `json:"first_name,omitempty"`
+	LastName             string   `json:"last_name,omitempty"`
+	Email                string   `json:"email,omitempty"`
+	URL                  string   `json:"url,omitempty"`
+	IM                   string   `json:"im,omitempty"`
+	ScreenName           string   `json:"screen_name,omitempty"`
+	Phone                string   `json:"phone,omitempty"`
+	AccountOwner         bool     `json:"account_owner,omitempty"`
+	ReceiveAnnouncements int      `json:"receive_announcements,omitempty"`
+	Bio                  string   `json:"bio,omitempty"`
+	Permissions          []string `json:"permissions,omitempty"`
+	Locale               string   `json:"locale,omitempty"`
+	UserType             string   `json:"user_type,omitempty"`
+	TfaEnabled           *bool    `json:"tfa_enabled,omitempty"`
+	AdminGraphqlAPIID    string   `json:"admin_graphql_api_id,omitempty"`
+}
+
+type UserResource struct {
+	User *User `json:"user"`
+}
+
+type UsersResource struct {
+	Users []User `json:"users"`
+}
+
+// usersUnavailableMessage is reported when the store doesn't expose its staff accounts
+const usersUnavailableMessage = "staff accounts are not available for this store. The Users API is only available to Shopify Plus stores and requires the read_users access scope"
+
+func tableShopifyUser(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "shopify_user",
+		Description: "Shopify user is a staff account that has access to the Shopify admin of the store.",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getUser,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listUsers,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Type:        proto.ColumnType_INT,
+				Description: "The user ID.",
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "email",
+				Type:        proto.ColumnType_STRING,
+				Description: "The user's email address.",
+			},
+			{
+				Name:        "first_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The user's first name.",
+			},
+			{
+				Name:        "last_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The user's last name.",
+			},
+			{
+				Name:        "account_owner",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the user is the owner of the Shopify account.",
+			},
+			{
+				Name:        "user_type",
+				Type:        proto.ColumnType_STRING,
+				Description: "The type of account the user has. Possible values are: regular, restricted, invited, requested, collaborator and collaborator_team_member.",
+			},
+			{
+				Name:        "permissions",
+				Type:        proto.ColumnType_JSON,
+				Description: "The permissions granted to the user's staff account.",
+			},
+			{
+				Name:        "locale",
+				Type:        proto.ColumnType_STRING,
+				Description: "The user's preferred locale.",
+			},
+			{
+				Name:        "tfa_enabled",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the user has two-step authentication enabled. This is null if the API doesn't expose it.",
+			},
+			{
+				Name:        "receive_announcements",
+				Type:        proto.ColumnType_BOOL,
+				Description: "Whether the user receives email announcements from Shopify.",
+				Transform:   transform.FromField("ReceiveAnnouncements").Transform(intToBool),
+			},
+			{
+				Name:        "phone",
+				Type:        proto.ColumnType_STRING,
+				Description: "The user's phone number.",
+			},
+			{
+				Name:        "screen_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The user's screen name.",
+			},
+			{
+				Name:        "im",
+				Type:        proto.ColumnType_STRING,
+				Description: "The user's instant messaging handle.",
+				Transform:   transform.FromField("IM"),
+			},
+			{
+				Name:        "url",
+				Type:        proto.ColumnType_STRING,
+				Description: "The user's homepage or other web address.",
+				Transform:   transform.FromField("URL"),
+			},
+			{
+				Name:        "bio",
+				Type:        proto.ColumnType_STRING,
+				Description: "The description the user has written for themselves.",
+			},
+			{
+				Name:        "admin_graphql_api_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The admin graphql API ID of the user.",
+				Transform:   transform.FromField("AdminGraphqlAPIID"),
+			},
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Type:        proto.ColumnType_STRING,
+				Description: "Title of the resource.",
+				Transform:   transform.FromField("Email"),
+			},
+		}),
+	}
+}
+
+func listUsers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_user.listUsers", "connection_error", err)
+		return nil, err
+	}
+
+	// the max limit defined by the API is 250
+	options := goshopify.ListOptions{
+		Limit: 250,
+	}
+
+	// set the limit if a lower limit is passed in query context
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < 250 {
+			options.Limit = int(*limit)
+		}
+	}
+
+	for {
+		result := new(UsersResource)
+		paginator, err := conn.ListWithPagination("users.json", result, options)
+		if err != nil {
+			plugin.Logger(ctx).Error("shopify_user.listUsers", "api_error", err)
+			if isUnavailableError(err) {
+				return nil, fmt.Errorf("%s: %v", usersUnavailableMessage, err)
+			}
+			return nil, err
+		}
+
+		for _, user := range result.Users {
+			d.StreamListItem(ctx, user)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if paginator.NextPageOptions == nil {
+			return nil, nil
+		}
+		options.PageInfo = paginator.NextPageOptions.PageInfo
+	}
+}
+
+func getUser(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	// check if the id is 0
+	if id == 0 {
+		return nil, nil
+	}
+
+	conn, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_user.getUser", "connection_error", err)
+		return nil, err
+	}
+
+	result := new(UserResource)
+	err = conn.Get(fmt.Sprintf("users/%d.json", id), result, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("shopify_user.getUser", "api_error", err)
+		// The message doesn't include the Not Found of the response, so the error
+		// isn't ignored like a missing user
+		if isUnavailableError(err) && usersUnavailable(conn) {
+			return nil, errors.New(usersUnavailableMessage)
+		}
+		return nil, err
+	}
+
+	return result.User, nil
+}
+
+// usersUnavailable returns true if the store doesn't expose its staff accounts. A missing
+// user and a store without the Users API both get a 404, so the users are listed to tell them apart.
+func usersUnavailable(conn *goshopify.Client) bool {
+	err := conn.Get("users.json", new(UsersResource), goshopify.ListOptions{Limit: 1})
+	return err != nil && isUnavailableError(err)
+}
@@ -17,3 +17,26 @@ func convertPrice(_ context.Context, d *transform.TransformData) (interface{}, e
 	}
 	return nil, nil
 }
+
+// intToBool converts the 0/1 integer flags used by some Shopify resources to a bool
+func intToBool(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	if d.Value == nil {
+		return nil, nil
+	}
+	switch v := d.Value.(type) {
+	case int:
+		return v != 0, nil
+	case int64:
+		return v != 0, nil
+	}
+	return nil, nil
+}
+
+// gidToID converts a GraphQL global ID to the numeric ID used by the REST resources
+func gidToID(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	gid, ok := d.Value.(string)
+	if !ok || gid == "" {
+		return nil, nil
+	}
+	return parseGID(gid), nil
+}